@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS cron_backfill_progress;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS cron_backfill_progress
+(
+    job_id       TEXT        NOT NULL,
+    period_start DATE        NOT NULL,
+    completed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job_id, period_start)
+);
+
+COMMIT;
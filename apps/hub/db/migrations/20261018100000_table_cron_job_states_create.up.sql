@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS cron_job_states
+(
+    job_id               TEXT        NOT NULL PRIMARY KEY,
+    consecutive_failures INTEGER     NOT NULL DEFAULT 0,
+    last_success_at      TIMESTAMPTZ,
+    last_failure_at      TIMESTAMPTZ,
+    last_error           TEXT,
+    alerted_at           TIMESTAMPTZ,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON cron_job_states
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+COMMIT;
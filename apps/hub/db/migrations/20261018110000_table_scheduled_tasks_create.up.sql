@@ -0,0 +1,27 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS scheduled_tasks
+(
+    id           UUID        NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    handler      TEXT        NOT NULL,
+    key          TEXT,
+    payload      BYTEA,
+    run_at       TIMESTAMPTZ NOT NULL,
+    every_ns     BIGINT      NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ,
+    run_count    INTEGER     NOT NULL DEFAULT 0,
+    last_run_at  TIMESTAMPTZ,
+    last_error   TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (handler, key)
+);
+
+CREATE INDEX idx_scheduled_tasks_run_at ON scheduled_tasks (run_at);
+
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON scheduled_tasks
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+COMMIT;
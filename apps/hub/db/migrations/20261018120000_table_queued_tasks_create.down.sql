@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS queued_tasks;
+DROP TYPE IF EXISTS queued_task_status;
+
+COMMIT;
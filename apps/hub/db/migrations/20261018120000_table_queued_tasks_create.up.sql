@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TYPE queued_task_status AS ENUM ('pending', 'running', 'succeeded', 'failed');
+
+CREATE TABLE IF NOT EXISTS queued_tasks
+(
+    id           UUID               NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    type         TEXT               NOT NULL,
+    payload      BYTEA,
+    status       queued_task_status NOT NULL DEFAULT 'pending',
+    unique_key   TEXT,
+    attempts     INTEGER            NOT NULL DEFAULT 0,
+    max_attempts INTEGER            NOT NULL,
+    backoff_ns   BIGINT             NOT NULL,
+    run_at       TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMPTZ,
+    last_error   TEXT,
+    completed_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ        NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_queued_tasks_status_run_at ON queued_tasks (status, run_at);
+
+CREATE UNIQUE INDEX idx_queued_tasks_active_unique_key ON queued_tasks (type, unique_key)
+    WHERE unique_key IS NOT NULL AND status IN ('pending', 'running');
+
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON queued_tasks
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS mqtt_dead_letters;
+
+COMMIT;
@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS mqtt_dead_letters
+(
+    id             UUID        NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    subscription   TEXT        NOT NULL,
+    subscriber_id  TEXT        NOT NULL,
+    topic          TEXT        NOT NULL,
+    relative_topic TEXT        NOT NULL,
+    payload        BYTEA,
+    last_error     TEXT        NOT NULL,
+    attempts       INTEGER     NOT NULL DEFAULT 1,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mqtt_dead_letters_subscriber_id_created_at
+    ON mqtt_dead_letters (subscriber_id, created_at);
+
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON mqtt_dead_letters
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS devices
+(
+    id            UUID        NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    name          TEXT        NOT NULL UNIQUE,
+    plugin_id     TEXT        NOT NULL,
+    password_hash TEXT        NOT NULL,
+    topics        TEXT[]      NOT NULL DEFAULT '{}',
+    last_seen_at  TIMESTAMPTZ,
+    revoked_at    TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_devices_plugin_id ON devices (plugin_id);
+
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON devices
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS notification_deliveries;
+DROP TYPE IF EXISTS notification_delivery_status;
+DROP TABLE IF EXISTS notification_messages;
+
+COMMIT;
@@ -0,0 +1,43 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS notification_messages
+(
+    id         UUID        NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    channel    TEXT        NOT NULL,
+    message    JSONB       NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON notification_messages
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+CREATE TYPE notification_delivery_status AS ENUM ('pending', 'running', 'delivered', 'failed');
+
+CREATE TABLE IF NOT EXISTS notification_deliveries
+(
+    id           UUID                         NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    message_id   UUID                         NOT NULL REFERENCES notification_messages (id) ON DELETE CASCADE,
+    transport    TEXT                         NOT NULL,
+    fallback     BOOLEAN                      NOT NULL DEFAULT FALSE,
+    status       notification_delivery_status NOT NULL DEFAULT 'pending',
+    attempts     INTEGER                      NOT NULL DEFAULT 0,
+    run_at       TIMESTAMPTZ                  NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMPTZ,
+    last_error   TEXT,
+    delivered_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ                  NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ                  NOT NULL DEFAULT NOW(),
+    UNIQUE (message_id, transport, fallback)
+);
+
+CREATE INDEX idx_notification_deliveries_status_run_at ON notification_deliveries (status, run_at);
+
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON notification_deliveries
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE scheduled_tasks
+    DROP COLUMN IF EXISTS failed_at,
+    DROP COLUMN IF EXISTS attempts;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE scheduled_tasks
+    ADD COLUMN IF NOT EXISTS attempts  INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS failed_at TIMESTAMPTZ;
+
+COMMIT;
@@ -0,0 +1,230 @@
+// Package alert provides core alerting policies that notify operators
+// about unhealthy background activity.
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/database"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+	"github.com/abgeo/maroid/apps/hub/internal/repository"
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+const timeLayout = "2006-01-02 15:04:05 MST"
+
+// CronPolicy tracks cron job outcomes and notifies the configured channel when a job
+// fails a number of consecutive times, and again once it recovers.
+// A single alert is sent per failure streak unless a repeat interval is configured.
+type CronPolicy struct {
+	cfg      config.CronAlerts
+	logger   *slog.Logger
+	db       *sqlx.DB
+	notifier notifierapi.Dispatcher
+}
+
+// NewCronPolicy creates a new CronPolicy.
+// It returns an error if the configured alert channel does not exist.
+func NewCronPolicy(
+	cfg *config.Config,
+	logger *slog.Logger,
+	db *sqlx.DB,
+	notifier notifierapi.Dispatcher,
+) (*CronPolicy, error) {
+	alertsCfg := cfg.Cron.Alerts
+
+	if alertsCfg.Channel != "" && !slices.Contains(notifier.Channels(), alertsCfg.Channel) {
+		return nil, fmt.Errorf("%w: %q", errs.ErrAlertChannelNotFound, alertsCfg.Channel)
+	}
+
+	return &CronPolicy{
+		cfg: alertsCfg,
+		logger: logger.With(
+			slog.String("component", "alert"),
+			slog.String("policy", "cron"),
+		),
+		db:       db,
+		notifier: notifier,
+	}, nil
+}
+
+// RecordSuccess resets the failure streak of the job and sends a recovery
+// message if a failure alert was sent for the streak.
+func (p *CronPolicy) RecordSuccess(ctx context.Context, jobID string) {
+	var previous model.CronJobState
+
+	_, err := p.updateState(ctx, jobID, func(state *model.CronJobState) {
+		previous = *state
+		now := time.Now()
+
+		state.ConsecutiveFailures = 0
+		state.LastSuccessAt = &now
+		state.LastError = nil
+		state.AlertedAt = nil
+	})
+	if err != nil {
+		p.logger.ErrorContext(ctx, "recording cron job success failed",
+			slog.String("job_id", jobID),
+			slog.Any("error", err),
+		)
+
+		return
+	}
+
+	if previous.AlertedAt == nil || !p.enabled() {
+		return
+	}
+
+	p.send(ctx, jobID, buildRecoveryMessage(jobID, &previous))
+}
+
+// RecordFailure extends the failure streak of the job and sends a failure alert
+// once the configured threshold is reached.
+func (p *CronPolicy) RecordFailure(ctx context.Context, jobID string, jobErr error) {
+	now := time.Now()
+
+	state, err := p.updateState(ctx, jobID, func(state *model.CronJobState) {
+		errText := jobErr.Error()
+
+		state.ConsecutiveFailures++
+		state.LastFailureAt = &now
+		state.LastError = &errText
+	})
+	if err != nil {
+		p.logger.ErrorContext(ctx, "recording cron job failure failed",
+			slog.String("job_id", jobID),
+			slog.Any("error", err),
+		)
+
+		return
+	}
+
+	if !p.enabled() || !p.shouldAlert(state, now) {
+		return
+	}
+
+	if !p.send(ctx, jobID, buildFailureMessage(jobID, state, jobErr)) {
+		return
+	}
+
+	_, err = p.updateState(ctx, jobID, func(state *model.CronJobState) {
+		state.AlertedAt = &now
+	})
+	if err != nil {
+		p.logger.ErrorContext(ctx, "recording cron job alert failed",
+			slog.String("job_id", jobID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (p *CronPolicy) enabled() bool {
+	return p.cfg.Channel != ""
+}
+
+func (p *CronPolicy) shouldAlert(state *model.CronJobState, now time.Time) bool {
+	if state.ConsecutiveFailures < p.cfg.FailureThreshold {
+		return false
+	}
+
+	if state.AlertedAt == nil {
+		return true
+	}
+
+	return p.cfg.RepeatInterval > 0 && now.Sub(*state.AlertedAt) >= p.cfg.RepeatInterval
+}
+
+func (p *CronPolicy) send(ctx context.Context, jobID string, msg notifierapi.Message) bool {
+	_, err := p.notifier.Send(ctx, p.cfg.Channel, msg)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "sending cron job alert failed",
+			slog.String("job_id", jobID),
+			slog.Any("error", err),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+func (p *CronPolicy) updateState(
+	ctx context.Context,
+	jobID string,
+	mutate func(state *model.CronJobState),
+) (*model.CronJobState, error) {
+	var state *model.CronJobState
+
+	err := database.WithTx(ctx, p.db, func(tx *sqlx.Tx) error {
+		repo := repository.NewCronJobState(tx)
+
+		var err error
+
+		state, err = repo.GetForUpdate(ctx, jobID)
+		if err != nil {
+			return err
+		}
+
+		mutate(state)
+
+		return repo.Upsert(ctx, state)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("updating cron job state: %w", err)
+	}
+
+	return state, nil
+}
+
+func buildFailureMessage(
+	jobID string,
+	state *model.CronJobState,
+	jobErr error,
+) notifierapi.Message {
+	return notifierapi.Message{
+		Title: "Cron job failed: " + jobID,
+		RichBody: notifierapi.RichText{
+			notifierapi.Bold("Consecutive failures"),
+			notifierapi.Text(fmt.Sprintf(": %d\n", state.ConsecutiveFailures)),
+			notifierapi.Bold("Last success"),
+			notifierapi.Text(": " + formatTime(state.LastSuccessAt) + "\n"),
+			notifierapi.Bold("Error"),
+			notifierapi.Text(": " + jobErr.Error()),
+		},
+	}
+}
+
+func buildRecoveryMessage(jobID string, previous *model.CronJobState) notifierapi.Message {
+	lastError := ""
+	if previous.LastError != nil {
+		lastError = *previous.LastError
+	}
+
+	return notifierapi.Message{
+		Title: "Cron job recovered: " + jobID,
+		RichBody: notifierapi.RichText{
+			notifierapi.Bold("Failed runs before recovery"),
+			notifierapi.Text(fmt.Sprintf(": %d\n", previous.ConsecutiveFailures)),
+			notifierapi.Bold("Previous success"),
+			notifierapi.Text(": " + formatTime(previous.LastSuccessAt) + "\n"),
+			notifierapi.Bold("Last error"),
+			notifierapi.Text(": " + lastError),
+		},
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+
+	return t.Format(timeLayout)
+}
@@ -0,0 +1,167 @@
+// Package backfill provides functionality for running backfillable cron jobs
+// against historical periods with rate limiting and resume support.
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/database"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+	"github.com/abgeo/maroid/apps/hub/internal/repository"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+const monthLayout = "2006-01"
+
+// Options controls a single backfill run.
+type Options struct {
+	// From is any moment within the first month to backfill.
+	From time.Time
+	// To is any moment within the last month to backfill (inclusive).
+	To time.Time
+	// Delay is the pause between two consecutive month executions.
+	Delay time.Duration
+	// Force re-runs months that have already been backfilled.
+	Force bool
+}
+
+// Runner executes backfillable jobs month by month and records completed months,
+// so an interrupted backfill can be resumed where it stopped.
+type Runner struct {
+	logger *slog.Logger
+	db     *sqlx.DB
+}
+
+// NewRunner creates a new Runner.
+func NewRunner(logger *slog.Logger, db *sqlx.DB) *Runner {
+	return &Runner{
+		logger: logger.With(
+			slog.String("component", "backfill"),
+		),
+		db: db,
+	}
+}
+
+// Run backfills the job for every month in the configured range.
+// Months that were already completed are skipped unless opts.Force is set.
+func (r *Runner) Run(ctx context.Context, job pluginapi.BackfillableJob, opts Options) error {
+	jobID := job.Meta().ID
+	first := pluginapi.MonthPeriod(opts.From).From
+	last := pluginapi.MonthPeriod(opts.To).From
+
+	if first.After(last) {
+		return fmt.Errorf(
+			"%w: %s is after %s",
+			errs.ErrInvalidBackfillRange,
+			first.Format(monthLayout),
+			last.Format(monthLayout),
+		)
+	}
+
+	logger := r.logger.With(slog.String("job_id", jobID))
+	executed := 0
+
+	for month := first; !month.After(last); month = month.AddDate(0, 1, 0) {
+		period := pluginapi.MonthPeriod(month)
+		monthLogger := logger.With(slog.String("month", month.Format(monthLayout)))
+
+		if !opts.Force {
+			completed, err := r.isCompleted(ctx, jobID, period)
+			if err != nil {
+				return err
+			}
+
+			if completed {
+				monthLogger.InfoContext(ctx, "month already backfilled, skipping")
+
+				continue
+			}
+		}
+
+		if executed > 0 {
+			if err := wait(ctx, opts.Delay); err != nil {
+				return err
+			}
+		}
+
+		executed++
+
+		monthLogger.InfoContext(ctx, "backfilling month")
+
+		if err := job.Backfill(ctx, period); err != nil {
+			return fmt.Errorf(
+				"backfilling job %s for %s: %w",
+				jobID,
+				month.Format(monthLayout),
+				err,
+			)
+		}
+
+		if err := r.markCompleted(ctx, jobID, period); err != nil {
+			return err
+		}
+
+		monthLogger.InfoContext(ctx, "month backfilled successfully")
+	}
+
+	logger.InfoContext(ctx, "backfill completed", slog.Int("executed_months", executed))
+
+	return nil
+}
+
+func (r *Runner) isCompleted(
+	ctx context.Context,
+	jobID string,
+	period pluginapi.Period,
+) (bool, error) {
+	var completed bool
+
+	err := database.WithTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		completed, err = repository.NewBackfillProgress(tx).Exists(ctx, jobID, period.From)
+
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("checking backfill progress: %w", err)
+	}
+
+	return completed, nil
+}
+
+func (r *Runner) markCompleted(ctx context.Context, jobID string, period pluginapi.Period) error {
+	err := database.WithTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		return repository.NewBackfillProgress(tx).Insert(ctx, &model.BackfillProgress{
+			JobID:       jobID,
+			PeriodStart: period.From,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("recording backfill progress: %w", err)
+	}
+
+	return nil
+}
+
+func wait(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("waiting between backfill runs: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
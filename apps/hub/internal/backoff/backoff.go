@@ -0,0 +1,18 @@
+// Package backoff computes capped exponential retry delays.
+package backoff
+
+import "time"
+
+// Exponential returns initial doubled once per retry, capped at limit.
+func Exponential(initial, limit time.Duration, retries int) time.Duration {
+	delay := initial
+
+	for range retries {
+		delay *= 2
+		if delay >= limit {
+			return limit
+		}
+	}
+
+	return min(delay, limit)
+}
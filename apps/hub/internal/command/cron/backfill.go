@@ -0,0 +1,159 @@
+package cron
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/backfill"
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+const (
+	monthLayout         = "2006-01"
+	defaultBackfillWait = 5 * time.Second
+)
+
+// BackfillCommand represents a command for backfilling historical data with a cron job.
+type BackfillCommand struct {
+	depResolver depresolver.Resolver
+	logger      *slog.Logger
+
+	from  string
+	to    string
+	delay time.Duration
+	force bool
+}
+
+// NewBackfillCommand creates a new BackfillCommand.
+func NewBackfillCommand(depResolver depresolver.Resolver) *BackfillCommand {
+	return &BackfillCommand{
+		depResolver: depResolver,
+		logger: depResolver.Logger().With(
+			slog.String("component", "command"),
+			slog.String("command", "cron backfill"),
+		),
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *BackfillCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "backfill <job>",
+		Short:   "Run a cron job month by month over a historical range",
+		Example: "  maroid cron backfill transactions_collector --from 2023-01 --to 2025-12",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&c.from, "from", "", "First month to backfill (YYYY-MM)")
+	cmd.Flags().StringVar(&c.to, "to", "", "Last month to backfill, inclusive (YYYY-MM)")
+	cmd.Flags().DurationVar(
+		&c.delay,
+		"delay",
+		defaultBackfillWait,
+		"Pause between two consecutive months to rate limit upstream APIs",
+	)
+	cmd.Flags().BoolVar(&c.force, "force", false, "Re-run months that have already been backfilled")
+
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func (c *BackfillCommand) run(cmd *cobra.Command, jobID string) error {
+	job, err := c.resolveJob(jobID)
+	if err != nil {
+		return err
+	}
+
+	location, err := c.location(job)
+	if err != nil {
+		return err
+	}
+
+	from, err := parseMonth(c.from, location)
+	if err != nil {
+		return err
+	}
+
+	to, err := parseMonth(c.to, location)
+	if err != nil {
+		return err
+	}
+
+	db, err := c.depResolver.Database()
+	if err != nil {
+		return fmt.Errorf("resolving database: %w", err)
+	}
+
+	runner := backfill.NewRunner(c.logger, db)
+
+	err = runner.Run(cmd.Context(), job, backfill.Options{
+		From:  from,
+		To:    to,
+		Delay: c.delay,
+		Force: c.force,
+	})
+	if err != nil {
+		return fmt.Errorf("running backfill: %w", err)
+	}
+
+	return nil
+}
+
+//nolint:ireturn
+func (c *BackfillCommand) resolveJob(jobID string) (pluginapi.BackfillableJob, error) {
+	cronRegistry, err := c.depResolver.CronRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("resolving cron registry: %w", err)
+	}
+
+	job, ok := cronRegistry.Get(jobID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errs.ErrCronJobNotFound, jobID)
+	}
+
+	backfillable, ok := job.(pluginapi.BackfillableJob)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errs.ErrCronJobNotBackfillable, jobID)
+	}
+
+	return backfillable, nil
+}
+
+// location returns the time zone the schedule of the job is evaluated in, so that the backfilled
+// months start at the same instants as the months of its scheduled runs.
+func (c *BackfillCommand) location(job pluginapi.CronJob) (*time.Location, error) {
+	parser, err := c.depResolver.CronScheduleParser()
+	if err != nil {
+		return nil, fmt.Errorf("resolving cron schedule parser: %w", err)
+	}
+
+	schedule, err := parser.Parse(job.Meta())
+	if err != nil {
+		return nil, fmt.Errorf("parsing schedule of cron job %s: %w", job.Meta().ID, err)
+	}
+
+	return schedule.Location, nil
+}
+
+func parseMonth(raw string, location *time.Location) (time.Time, error) {
+	month, err := time.ParseInLocation(monthLayout, raw, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"%w: %q is not in YYYY-MM format",
+			errs.ErrInvalidBackfillRange,
+			raw,
+		)
+	}
+
+	return month, nil
+}
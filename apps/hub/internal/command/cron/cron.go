@@ -0,0 +1,36 @@
+// Package cron provides Cobra commands for managing cron jobs.
+package cron
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+// Command represents a command for managing cron jobs.
+type Command struct {
+	depResolver depresolver.Resolver
+}
+
+// New creates a new Command.
+func New(depResolver depresolver.Resolver) *Command {
+	return &Command{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *Command) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cron",
+		Short: "Commands to manage cron jobs",
+	}
+
+	cmd.AddCommand(
+		NewBackfillCommand(c.depResolver).Command(),
+		NewListCommand(c.depResolver).Command(),
+		NewRunCommand(c.depResolver).Command(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,91 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+const (
+	defaultPreviewRuns = 3
+	previewTimeLayout  = "2006-01-02 15:04:05 MST"
+)
+
+// ListCommand represents a command for listing registered cron jobs and their upcoming runs.
+type ListCommand struct {
+	depResolver depresolver.Resolver
+
+	next int
+}
+
+// NewListCommand creates a new ListCommand.
+func NewListCommand(depResolver depresolver.Resolver) *ListCommand {
+	return &ListCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *ListCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List registered cron jobs with their next runs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.run(cmd)
+		},
+	}
+
+	cmd.Flags().IntVarP(&c.next, "next", "n", defaultPreviewRuns, "Number of upcoming runs to show")
+
+	return cmd
+}
+
+func (c *ListCommand) run(cmd *cobra.Command) error {
+	cronRegistry, err := c.depResolver.CronRegistry()
+	if err != nil {
+		return fmt.Errorf("resolving cron registry: %w", err)
+	}
+
+	parser, err := c.depResolver.CronScheduleParser()
+	if err != nil {
+		return fmt.Errorf("resolving cron schedule parser: %w", err)
+	}
+
+	previews := parser.Previews(cronRegistry.All(), time.Now(), c.next)
+
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(writer, "JOB\tSCHEDULE\tTIMEZONE\tNEXT RUNS")
+
+	for _, preview := range previews {
+		nextRuns := make([]string, 0, len(preview.NextRuns))
+		for _, run := range preview.NextRuns {
+			nextRuns = append(nextRuns, run.Format(previewTimeLayout))
+		}
+
+		if preview.Error != "" {
+			nextRuns = []string{"error: " + preview.Error}
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\n",
+			preview.JobID,
+			preview.Schedule,
+			preview.Timezone,
+			strings.Join(nextRuns, ", "),
+		)
+	}
+
+	if err = writer.Flush(); err != nil {
+		return fmt.Errorf("writing cron job list: %w", err)
+	}
+
+	return nil
+}
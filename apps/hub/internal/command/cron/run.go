@@ -0,0 +1,100 @@
+package cron
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/cronrun"
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+// RunCommand represents a command for running a cron job on demand.
+type RunCommand struct {
+	depResolver depresolver.Resolver
+	logger      *slog.Logger
+
+	dryRun bool
+}
+
+// NewRunCommand creates a new RunCommand.
+func NewRunCommand(depResolver depresolver.Resolver) *RunCommand {
+	return &RunCommand{
+		depResolver: depResolver,
+		logger: depResolver.Logger().With(
+			slog.String("component", "command"),
+			slog.String("command", "cron run"),
+		),
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *RunCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "run <job>",
+		Short:   "Run a cron job once",
+		Example: "  maroid cron run transactions_collector --dry-run",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&c.dryRun,
+		"dry-run",
+		false,
+		"Roll back database writes, record notifications and only log Telegram messages",
+	)
+
+	return cmd
+}
+
+func (c *RunCommand) run(cmd *cobra.Command, jobID string) error {
+	cronRegistry, err := c.depResolver.CronRegistry()
+	if err != nil {
+		return fmt.Errorf("resolving cron registry: %w", err)
+	}
+
+	result, err := cronrun.NewRunner(c.logger, cronRegistry).Run(cmd.Context(), jobID, c.dryRun)
+	if err != nil {
+		return fmt.Errorf("running cron job: %w", err)
+	}
+
+	if c.dryRun {
+		c.printNotifications(cmd, result)
+	}
+
+	if result.Err != nil {
+		return fmt.Errorf("running cron job %s: %w", jobID, result.Err)
+	}
+
+	return nil
+}
+
+func (c *RunCommand) printNotifications(cmd *cobra.Command, result *cronrun.Result) {
+	out := cmd.OutOrStdout()
+
+	_, _ = fmt.Fprintf(out, "Recorded notifications: %d\n", len(result.Notifications))
+
+	for _, notification := range result.Notifications {
+		_, _ = fmt.Fprintf(
+			out,
+			"\n[%s] %s\n%s\n",
+			notification.Channel,
+			notification.Message.Title,
+			notification.Message.Text(),
+		)
+
+		for _, attachment := range notification.Message.Attachments {
+			_, _ = fmt.Fprintf(
+				out,
+				"  attachment: %s (%s, %d bytes)\n",
+				attachment.Filename,
+				attachment.MIMEType,
+				len(attachment.Content),
+			)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package devices
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+// AddCommand represents a command for registering a device.
+type AddCommand struct {
+	depResolver depresolver.Resolver
+
+	pluginID string
+	topics   []string
+}
+
+// NewAddCommand creates a new AddCommand.
+func NewAddCommand(depResolver depresolver.Resolver) *AddCommand {
+	return &AddCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *AddCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Register a device and print its generated password",
+		Long: "Register a device of a plugin. The device authenticates with its name and the\n" +
+			"generated password, which is printed once and cannot be retrieved later. Topics are\n" +
+			"topic filters relative to the plugin namespace.",
+		Example: "  maroid devices add flora-node-1 --plugin dev.maroid.jasmine " +
+			"--topic 'plant/+/measurement/#'",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args[0])
+		},
+	}
+
+	cmd.Flags().StringVarP(&c.pluginID, "plugin", "p", "", "ID of the plugin the device belongs to")
+	cmd.Flags().StringArrayVarP(
+		&c.topics,
+		"topic",
+		"t",
+		nil,
+		"Topic filter the device may publish and subscribe to (repeatable)",
+	)
+
+	_ = cmd.MarkFlagRequired("plugin")
+
+	return cmd
+}
+
+func (c *AddCommand) run(cmd *cobra.Command, name string) error {
+	registry, err := c.depResolver.DeviceRegistry()
+	if err != nil {
+		return fmt.Errorf("resolving device registry: %w", err)
+	}
+
+	entity, password, err := registry.Add(cmd.Context(), name, c.pluginID, c.topics)
+	if err != nil {
+		return fmt.Errorf("adding device %s: %w", name, err)
+	}
+
+	out := cmd.OutOrStdout()
+
+	_, _ = fmt.Fprintf(out, "ID:       %s\n", entity.ID)
+	_, _ = fmt.Fprintf(out, "Username: %s\n", entity.Name)
+	_, _ = fmt.Fprintf(out, "Password: %s\n", password)
+	_, _ = fmt.Fprintf(out, "Plugin:   %s\n", entity.PluginID)
+	_, _ = fmt.Fprintf(out, "Topics:   %s\n", strings.Join(entity.Topics, ", "))
+
+	return nil
+}
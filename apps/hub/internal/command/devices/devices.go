@@ -0,0 +1,36 @@
+// Package devices provides Cobra commands for managing the devices allowed to connect to the MQTT broker.
+package devices
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+// Command represents a command for managing devices.
+type Command struct {
+	depResolver depresolver.Resolver
+}
+
+// New creates a new Command.
+func New(depResolver depresolver.Resolver) *Command {
+	return &Command{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *Command) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "devices",
+		Short: "Commands to manage devices connecting to the MQTT broker",
+	}
+
+	cmd.AddCommand(
+		NewAddCommand(c.depResolver).Command(),
+		NewListCommand(c.depResolver).Command(),
+		NewRevokeCommand(c.depResolver).Command(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,91 @@
+package devices
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+const timeLayout = "2006-01-02 15:04:05 MST"
+
+// ListCommand represents a command for listing registered devices.
+type ListCommand struct {
+	depResolver depresolver.Resolver
+
+	pluginID string
+}
+
+// NewListCommand creates a new ListCommand.
+func NewListCommand(depResolver depresolver.Resolver) *ListCommand {
+	return &ListCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *ListCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List registered devices",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.run(cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&c.pluginID, "plugin", "p", "", "Only list devices of this plugin")
+
+	return cmd
+}
+
+func (c *ListCommand) run(cmd *cobra.Command) error {
+	registry, err := c.depResolver.DeviceRegistry()
+	if err != nil {
+		return fmt.Errorf("resolving device registry: %w", err)
+	}
+
+	entities, err := registry.List(cmd.Context(), c.pluginID)
+	if err != nil {
+		return fmt.Errorf("listing devices: %w", err)
+	}
+
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(writer, "NAME\tPLUGIN\tTOPICS\tLAST SEEN\tSTATUS")
+
+	for _, entity := range entities {
+		status := "active"
+		if entity.RevokedAt != nil {
+			status = "revoked " + formatTime(entity.RevokedAt)
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\n",
+			entity.Name,
+			entity.PluginID,
+			strings.Join(entity.Topics, ", "),
+			formatTime(entity.LastSeenAt),
+			status,
+		)
+	}
+
+	if err = writer.Flush(); err != nil {
+		return fmt.Errorf("writing device list: %w", err)
+	}
+
+	return nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+
+	return t.Local().Format(timeLayout)
+}
@@ -0,0 +1,55 @@
+package devices
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+// RevokeCommand represents a command for revoking devices.
+type RevokeCommand struct {
+	depResolver depresolver.Resolver
+}
+
+// NewRevokeCommand creates a new RevokeCommand.
+func NewRevokeCommand(depResolver depresolver.Resolver) *RevokeCommand {
+	return &RevokeCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *RevokeCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke <name>...",
+		Short: "Revoke devices so that they can no longer connect",
+		Long: "Revoke devices so that they can no longer connect.\n\n" +
+			"Connected devices are disconnected by the embedded broker once it refreshes " +
+			"the grants of its clients (mqtt.embedded.grant_refresh_interval).",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args)
+		},
+	}
+
+	return cmd
+}
+
+func (c *RevokeCommand) run(cmd *cobra.Command, names []string) error {
+	registry, err := c.depResolver.DeviceRegistry()
+	if err != nil {
+		return fmt.Errorf("resolving device registry: %w", err)
+	}
+
+	for _, name := range names {
+		if err = registry.Revoke(cmd.Context(), name); err != nil {
+			return fmt.Errorf("revoking device %s: %w", name, err)
+		}
+
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Revoked: %s\n", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package mqtt
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+const defaultDLQLimit = 100
+
+// DLQCommand represents a command for managing dead-lettered MQTT messages.
+type DLQCommand struct {
+	depResolver depresolver.Resolver
+}
+
+// NewDLQCommand creates a new DLQCommand.
+func NewDLQCommand(depResolver depresolver.Resolver) *DLQCommand {
+	return &DLQCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *DLQCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dlq",
+		Short: "Commands to manage MQTT messages subscribers failed to handle",
+	}
+
+	cmd.AddCommand(
+		NewDLQListCommand(c.depResolver).Command(),
+		NewDLQReplayCommand(c.depResolver).Command(),
+		NewDLQPurgeCommand(c.depResolver).Command(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+package mqtt
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+const (
+	dlqTimeLayout   = "2006-01-02 15:04:05 MST"
+	maxErrorDisplay = 80
+)
+
+// DLQListCommand represents a command for listing dead-lettered MQTT messages.
+type DLQListCommand struct {
+	depResolver depresolver.Resolver
+
+	subscriberID string
+	limit        int
+}
+
+// NewDLQListCommand creates a new DLQListCommand.
+func NewDLQListCommand(depResolver depresolver.Resolver) *DLQListCommand {
+	return &DLQListCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *DLQListCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List dead-lettered MQTT messages, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.run(cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&c.subscriberID,
+		"subscriber",
+		"s",
+		"",
+		"Only list messages of this subscriber",
+	)
+	cmd.Flags().IntVarP(&c.limit, "limit", "l", defaultDLQLimit, "Maximum number of messages to list")
+
+	return cmd
+}
+
+func (c *DLQListCommand) run(cmd *cobra.Command) error {
+	store, err := c.depResolver.MQTTDeadLetterStore()
+	if err != nil {
+		return fmt.Errorf("resolving MQTT dead-letter store: %w", err)
+	}
+
+	entities, err := store.List(cmd.Context(), c.subscriberID, c.limit)
+	if err != nil {
+		return fmt.Errorf("listing MQTT dead letters: %w", err)
+	}
+
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(writer, "ID\tSUBSCRIBER\tTOPIC\tATTEMPTS\tCREATED\tPAYLOAD\tERROR")
+
+	for _, entity := range entities {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%d\t%s\t%d bytes\t%s\n",
+			entity.ID,
+			entity.SubscriberID,
+			entity.Topic,
+			entity.Attempts,
+			entity.CreatedAt.Local().Format(dlqTimeLayout),
+			len(entity.Payload),
+			truncate(entity.LastError),
+		)
+	}
+
+	if err = writer.Flush(); err != nil {
+		return fmt.Errorf("writing MQTT dead letter list: %w", err)
+	}
+
+	return nil
+}
+
+func truncate(message string) string {
+	message = strings.ReplaceAll(message, "\n", " ")
+
+	if len(message) <= maxErrorDisplay {
+		return message
+	}
+
+	return message[:maxErrorDisplay-1] + "…"
+}
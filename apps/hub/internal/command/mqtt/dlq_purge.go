@@ -0,0 +1,91 @@
+package mqtt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+)
+
+// DLQPurgeCommand represents a command for removing dead-lettered MQTT messages.
+type DLQPurgeCommand struct {
+	depResolver depresolver.Resolver
+
+	all          bool
+	subscriberID string
+	olderThan    time.Duration
+}
+
+// NewDLQPurgeCommand creates a new DLQPurgeCommand.
+func NewDLQPurgeCommand(depResolver depresolver.Resolver) *DLQPurgeCommand {
+	return &DLQPurgeCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *DLQPurgeCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge [id...]",
+		Short: "Remove dead-lettered MQTT messages without replaying them",
+		Example: "  maroid mqtt dlq purge 6f1c2a4e-8d0b-4f7a-9a51-3c2d8e7b1f90\n" +
+			"  maroid mqtt dlq purge --subscriber measurement --older-than 168h\n" +
+			"  maroid mqtt dlq purge --all",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&c.all, "all", false, "Remove all messages")
+	cmd.Flags().StringVarP(
+		&c.subscriberID,
+		"subscriber",
+		"s",
+		"",
+		"Only remove messages of this subscriber",
+	)
+	cmd.Flags().DurationVar(&c.olderThan, "older-than", 0, "Only remove messages older than this")
+
+	return cmd
+}
+
+func (c *DLQPurgeCommand) run(cmd *cobra.Command, ids []string) error {
+	filtered := c.all || c.subscriberID != "" || c.olderThan > 0
+	if filtered == (len(ids) > 0) {
+		return fmt.Errorf(
+			"%w: pass either message IDs or one of --all, --subscriber, --older-than",
+			errs.ErrInvalidCommandArguments,
+		)
+	}
+
+	store, err := c.depResolver.MQTTDeadLetterStore()
+	if err != nil {
+		return fmt.Errorf("resolving MQTT dead-letter store: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+
+	if !filtered {
+		for _, id := range ids {
+			if err = store.Delete(cmd.Context(), id); err != nil {
+				return fmt.Errorf("removing MQTT dead letter %s: %w", id, err)
+			}
+		}
+
+		_, _ = fmt.Fprintf(out, "Removed: %d\n", len(ids))
+
+		return nil
+	}
+
+	count, err := store.Purge(cmd.Context(), c.subscriberID, time.Now().Add(-c.olderThan))
+	if err != nil {
+		return fmt.Errorf("purging MQTT dead letters: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(out, "Removed: %d\n", count)
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package mqtt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+)
+
+// DLQReplayCommand represents a command for re-dispatching dead-lettered MQTT messages.
+type DLQReplayCommand struct {
+	depResolver depresolver.Resolver
+
+	all          bool
+	subscriberID string
+	limit        int
+}
+
+// NewDLQReplayCommand creates a new DLQReplayCommand.
+func NewDLQReplayCommand(depResolver depresolver.Resolver) *DLQReplayCommand {
+	return &DLQReplayCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *DLQReplayCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay [id...]",
+		Short: "Re-dispatch dead-lettered MQTT messages to their subscribers",
+		Long: "Re-dispatch dead-lettered MQTT messages to their subscribers.\n" +
+			"Handled messages are removed; failing ones stay with an increased attempt count.",
+		Example: "  maroid mqtt dlq replay 6f1c2a4e-8d0b-4f7a-9a51-3c2d8e7b1f90\n" +
+			"  maroid mqtt dlq replay --all --subscriber measurement",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&c.all, "all", false, "Replay all messages instead of the given IDs")
+	cmd.Flags().StringVarP(
+		&c.subscriberID,
+		"subscriber",
+		"s",
+		"",
+		"With --all, only replay messages of this subscriber",
+	)
+	cmd.Flags().IntVarP(
+		&c.limit,
+		"limit",
+		"l",
+		defaultDLQLimit,
+		"With --all, maximum number of messages to replay",
+	)
+
+	return cmd
+}
+
+func (c *DLQReplayCommand) run(cmd *cobra.Command, ids []string) error {
+	if c.all == (len(ids) > 0) {
+		return fmt.Errorf("%w: pass either message IDs or --all", errs.ErrInvalidCommandArguments)
+	}
+
+	store, err := c.depResolver.MQTTDeadLetterStore()
+	if err != nil {
+		return fmt.Errorf("resolving MQTT dead-letter store: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+
+	if c.all {
+		replayed, failed, err := store.ReplayAll(cmd.Context(), c.subscriberID, c.limit)
+		if err != nil {
+			return fmt.Errorf("replaying MQTT dead letters: %w", err)
+		}
+
+		_, _ = fmt.Fprintf(out, "Replayed: %d, failed again: %d\n", replayed, failed)
+
+		return nil
+	}
+
+	var failed int
+
+	for _, id := range ids {
+		err = store.Replay(cmd.Context(), id)
+
+		switch {
+		case err == nil:
+			_, _ = fmt.Fprintf(out, "%s: replayed\n", id)
+		case errors.Is(err, errs.ErrMQTTDeadLetterReplayFailed):
+			failed++
+
+			_, _ = fmt.Fprintf(out, "%s: %v\n", id, err)
+		default:
+			return fmt.Errorf("replaying MQTT dead letter %s: %w", id, err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d messages", errs.ErrMQTTDeadLetterReplayFailed, failed, len(ids))
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+// Package mqtt provides Cobra commands for managing MQTT messaging.
+package mqtt
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+// Command represents a command for managing MQTT messaging.
+type Command struct {
+	depResolver depresolver.Resolver
+}
+
+// New creates a new Command.
+func New(depResolver depresolver.Resolver) *Command {
+	return &Command{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *Command) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mqtt",
+		Short: "Commands to manage MQTT messaging",
+	}
+
+	cmd.AddCommand(
+		NewDLQCommand(c.depResolver).Command(),
+		NewPublishCommand(c.depResolver).Command(),
+		NewTailCommand(c.depResolver).Command(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,115 @@
+package mqtt
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// payloadArg is the position of the optional payload argument.
+const payloadArg = 2
+
+// PublishCommand represents a command for publishing a test message to a plugin namespace.
+type PublishCommand struct {
+	depResolver depresolver.Resolver
+
+	file     string
+	qos      byte
+	retained bool
+}
+
+// NewPublishCommand creates a new PublishCommand.
+func NewPublishCommand(depResolver depresolver.Resolver) *PublishCommand {
+	return &PublishCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *PublishCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "publish <plugin-id> <topic> [payload]",
+		Short: "Publish a message to a topic relative to a plugin namespace",
+		Example: "  maroid mqtt publish dev.maroid.jasmine plant/1/measurement/moisture " +
+			"'{\"time\":\"2026-10-19T10:00:00Z\",\"value\":41.5}'\n" +
+			"  maroid mqtt publish dev.maroid.jasmine plant/1/measurement/moisture --file reading.json",
+		Args: cobra.RangeArgs(payloadArg, payloadArg+1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&c.file, "file", "f", "", `Read the payload from a file ("-" for stdin)`)
+	cmd.Flags().Uint8VarP(&c.qos, "qos", "q", 1, "QoS level of the message")
+	cmd.Flags().BoolVarP(&c.retained, "retain", "r", false, "Ask the broker to retain the message")
+
+	return cmd
+}
+
+func (c *PublishCommand) run(cmd *cobra.Command, args []string) error {
+	pluginID := pluginapi.ParsePluginID(args[0])
+	if pluginID == nil {
+		return fmt.Errorf("%w: %q", errs.ErrInvalidPluginID, args[0])
+	}
+
+	if err := mqttclient.ValidateRelativeTopic(args[1], false); err != nil {
+		return fmt.Errorf("invalid topic: %w", err)
+	}
+
+	payload, err := c.payload(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	topic := mqttclient.Namespace(pluginID) + "/" + args[1]
+
+	publisher, err := c.depResolver.MQTTPublisher()
+	if err != nil {
+		return fmt.Errorf("resolving MQTT publisher: %w", err)
+	}
+
+	err = publisher.Publish(cmd.Context(), topic, payload, c.qos, c.retained)
+	if err != nil {
+		return fmt.Errorf("publishing to %s: %w", topic, err)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Published %d bytes to %s\n", len(payload), topic)
+
+	return nil
+}
+
+func (c *PublishCommand) payload(cmd *cobra.Command, args []string) ([]byte, error) {
+	if (c.file != "") == (len(args) > payloadArg) {
+		return nil, fmt.Errorf(
+			"%w: pass either a payload argument or --file",
+			errs.ErrInvalidCommandArguments,
+		)
+	}
+
+	if c.file == "" {
+		return []byte(args[payloadArg]), nil
+	}
+
+	if c.file == "-" {
+		payload, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("reading payload from stdin: %w", err)
+		}
+
+		return payload, nil
+	}
+
+	payload, err := os.ReadFile(c.file)
+	if err != nil {
+		return nil, fmt.Errorf("reading payload file: %w", err)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,230 @@
+package mqtt
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+const (
+	tailTimeLayout = "15:04:05.000"
+	// tailTopicArg is the position of the optional topic argument.
+	tailTopicArg = 1
+)
+
+// TailCommand represents a command for printing MQTT messages of a plugin namespace.
+type TailCommand struct {
+	depResolver depresolver.Resolver
+
+	qos byte
+	raw bool
+}
+
+// NewTailCommand creates a new TailCommand.
+func NewTailCommand(depResolver depresolver.Resolver) *TailCommand {
+	return &TailCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *TailCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tail <plugin-id> [topic]",
+		Short: "Print MQTT messages of a plugin namespace as they arrive",
+		Long: "Subscribe to the namespace of a plugin, or to a topic filter relative to it, and\n" +
+			"print every message together with the registered subscribers it would be delivered to.\n" +
+			"Without mqtt.broker, the command connects to the local listener of the embedded broker.",
+		Example: "  maroid mqtt tail dev.maroid.jasmine\n" +
+			"  maroid mqtt tail dev.maroid.jasmine 'plant/+/measurement/#'",
+		Args: cobra.RangeArgs(1, tailTopicArg+1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			topic := "#"
+			if len(args) > tailTopicArg {
+				topic = args[tailTopicArg]
+			}
+
+			return c.run(cmd, args[0], topic)
+		},
+	}
+
+	cmd.Flags().Uint8VarP(&c.qos, "qos", "q", 0, "QoS level of the subscription")
+	cmd.Flags().BoolVar(&c.raw, "raw", false, "Print payloads as received, without formatting")
+
+	return cmd
+}
+
+func (c *TailCommand) run(cmd *cobra.Command, rawPluginID string, topic string) error {
+	ctx := cmd.Context()
+
+	pluginID := pluginapi.ParsePluginID(rawPluginID)
+	if pluginID == nil {
+		return fmt.Errorf("%w: %q", errs.ErrInvalidPluginID, rawPluginID)
+	}
+
+	if err := mqttclient.ValidateRelativeTopic(topic, true); err != nil {
+		return fmt.Errorf("invalid topic: %w", err)
+	}
+
+	subscriberRegistry, err := c.depResolver.MQTTSubscriberRegistry()
+	if err != nil {
+		return fmt.Errorf("resolving MQTT subscriber registry: %w", err)
+	}
+
+	namespace := mqttclient.Namespace(pluginID)
+	printer := &tailPrinter{
+		out:         cmd.OutOrStdout(),
+		namespace:   namespace,
+		subscribers: subscriberRegistry,
+		raw:         c.raw,
+	}
+
+	subscription := mqttclient.Subscription{
+		Topic:   namespace + "/" + topic,
+		QoS:     c.qos,
+		Handler: printer.print,
+	}
+
+	return c.tail(ctx, cmd.ErrOrStderr(), subscription)
+}
+
+// tail subscribes on every (re)connect and blocks until ctx is done or subscribing fails.
+func (c *TailCommand) tail(
+	ctx context.Context,
+	errOut io.Writer,
+	subscription mqttclient.Subscription,
+) error {
+	cfg := c.depResolver.Config().MQTT
+	subscribeErrs := make(chan error, 1)
+
+	broker, err := mqttclient.BrokerURL(cfg)
+	if err != nil {
+		return fmt.Errorf("resolving MQTT broker: %w", err)
+	}
+
+	cfg.Broker = broker
+
+	var conn mqttclient.Conn
+
+	conn, err = mqttclient.New(cfg, "tail", c.depResolver.Logger(), mqttclient.Callbacks{
+		OnConnect: func() {
+			if err := conn.Subscribe(ctx, subscription); err != nil {
+				select {
+				case subscribeErrs <- err:
+				default:
+				}
+
+				return
+			}
+
+			_, _ = fmt.Fprintf(errOut, "Subscribed to %s on %s\n", subscription.Topic, cfg.Broker)
+		},
+		OnConnectionLost: func(err error) {
+			_, _ = fmt.Fprintf(errOut, "Connection lost: %v\n", err)
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("creating MQTT connection: %w", err)
+	}
+
+	if _, err = conn.Connect(ctx); err != nil {
+		return fmt.Errorf("connecting to MQTT broker: %w", err)
+	}
+
+	defer conn.Disconnect()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err = <-subscribeErrs:
+		return fmt.Errorf("subscribing to %s: %w", subscription.Topic, err)
+	}
+}
+
+// tailPrinter prints received messages; messages may arrive concurrently.
+type tailPrinter struct {
+	out         io.Writer
+	namespace   string
+	subscribers *registry.MQTTSubscriberRegistry
+	raw         bool
+
+	mu sync.Mutex
+}
+
+func (p *tailPrinter) print(msg mqttclient.Message) {
+	var buf bytes.Buffer
+
+	_, _ = fmt.Fprintf(
+		&buf,
+		"%s %s (%d bytes)\n",
+		time.Now().Format(tailTimeLayout),
+		strings.TrimPrefix(msg.Topic, p.namespace+"/"),
+		len(msg.Payload),
+	)
+	_, _ = fmt.Fprintf(&buf, "  subscribers: %s\n", p.matchingSubscribers(msg.Topic))
+
+	for _, prop := range msg.Properties.UserProperties {
+		_, _ = fmt.Fprintf(&buf, "  property: %s=%s\n", prop.Key, prop.Value)
+	}
+
+	buf.WriteString(p.formatPayload(msg.Payload))
+	buf.WriteString("\n")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, _ = p.out.Write(buf.Bytes())
+}
+
+func (p *tailPrinter) matchingSubscribers(topic string) string {
+	subscribers := p.subscribers.All()
+
+	ids := make([]string, 0, len(subscribers))
+
+	for _, effectiveTopic := range slices.Sorted(maps.Keys(subscribers)) {
+		if mqttclient.FilterCovers(effectiveTopic, topic) {
+			ids = append(ids, subscribers[effectiveTopic].Meta().ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return "none"
+	}
+
+	return strings.Join(ids, ", ")
+}
+
+// formatPayload indents JSON payloads, prints other text as is and dumps binary payloads in hex.
+func (p *tailPrinter) formatPayload(payload []byte) string {
+	if p.raw {
+		return string(payload) + "\n"
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, payload, "  ", "  "); err == nil {
+		return "  " + indented.String() + "\n"
+	}
+
+	if utf8.Valid(payload) {
+		return "  " + string(payload) + "\n"
+	}
+
+	return hex.Dump(payload)
+}
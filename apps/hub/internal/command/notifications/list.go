@@ -0,0 +1,145 @@
+package notifications
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+)
+
+const (
+	timeLayout      = "2006-01-02 15:04:05 MST"
+	defaultLimit    = 100
+	maxTitleDisplay = 40
+	maxErrorDisplay = 80
+)
+
+var statuses = []model.NotificationDeliveryStatus{
+	model.NotificationDeliveryStatusPending,
+	model.NotificationDeliveryStatusRunning,
+	model.NotificationDeliveryStatusDelivered,
+	model.NotificationDeliveryStatusFailed,
+}
+
+// ListCommand represents a command for listing outbox notifications.
+type ListCommand struct {
+	depResolver depresolver.Resolver
+
+	status string
+	limit  int
+}
+
+// NewListCommand creates a new ListCommand.
+func NewListCommand(depResolver depresolver.Resolver) *ListCommand {
+	return &ListCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *ListCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List undelivered notifications with their per-transport status, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.run(cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&c.status,
+		"status",
+		"s",
+		"",
+		"Only list notifications with a delivery in this status: pending, running, delivered, failed",
+	)
+	cmd.Flags().IntVarP(
+		&c.limit,
+		"limit",
+		"l",
+		defaultLimit,
+		"Maximum number of notifications to list",
+	)
+
+	return cmd
+}
+
+func (c *ListCommand) run(cmd *cobra.Command) error {
+	status := model.NotificationDeliveryStatus(c.status)
+	if status != "" && !slices.Contains(statuses, status) {
+		return fmt.Errorf("%w: unknown status %q", errs.ErrInvalidCommandArguments, c.status)
+	}
+
+	notificationOutbox, err := c.depResolver.NotificationOutbox()
+	if err != nil {
+		return fmt.Errorf("resolving notification outbox: %w", err)
+	}
+
+	entries, err := notificationOutbox.List(cmd.Context(), status, c.limit)
+	if err != nil {
+		return fmt.Errorf("listing notifications: %w", err)
+	}
+
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(
+		writer,
+		"ID\tCHANNEL\tTITLE\tCREATED\tTRANSPORT\tSTATUS\tATTEMPTS\tNEXT ATTEMPT\tERROR",
+	)
+
+	for _, entry := range entries {
+		for _, delivery := range entry.Deliveries {
+			transport := delivery.Transport
+			if delivery.Fallback {
+				transport += " (fallback)"
+			}
+
+			nextAttempt := "-"
+			if delivery.Status == model.NotificationDeliveryStatusPending {
+				nextAttempt = delivery.RunAt.Local().Format(timeLayout)
+			}
+
+			lastError := ""
+			if delivery.LastError != nil {
+				lastError = *delivery.LastError
+			}
+
+			_, _ = fmt.Fprintf(
+				writer,
+				"%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+				entry.Message.ID,
+				entry.Message.Channel,
+				truncate(entry.Title, maxTitleDisplay),
+				entry.Message.CreatedAt.Local().Format(timeLayout),
+				transport,
+				delivery.Status,
+				delivery.Attempts,
+				nextAttempt,
+				truncate(lastError, maxErrorDisplay),
+			)
+		}
+	}
+
+	if err = writer.Flush(); err != nil {
+		return fmt.Errorf("writing notification list: %w", err)
+	}
+
+	return nil
+}
+
+func truncate(text string, limit int) string {
+	runes := []rune(strings.ReplaceAll(text, "\n", " "))
+
+	if len(runes) <= limit {
+		return string(runes)
+	}
+
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,36 @@
+// Package notifications provides Cobra commands for inspecting and re-sending
+// notifications in the notification outbox.
+package notifications
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+// Command represents a command for managing outbox notifications.
+type Command struct {
+	depResolver depresolver.Resolver
+}
+
+// New creates a new Command.
+func New(depResolver depresolver.Resolver) *Command {
+	return &Command{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *Command) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notifications",
+		Short: "Commands to inspect and re-send notifications in the outbox",
+	}
+
+	cmd.AddCommand(
+		NewListCommand(c.depResolver).Command(),
+		NewResendCommand(c.depResolver).Command(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,55 @@
+package notifications
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+)
+
+// ResendCommand represents a command for re-sending failed outbox notifications.
+type ResendCommand struct {
+	depResolver depresolver.Resolver
+}
+
+// NewResendCommand creates a new ResendCommand.
+func NewResendCommand(depResolver depresolver.Resolver) *ResendCommand {
+	return &ResendCommand{
+		depResolver: depResolver,
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *ResendCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resend <id>...",
+		Short: "Re-send notifications through the transports that failed to deliver them",
+		Long: "Re-send notifications through the transports that failed to deliver them.\n" +
+			"The failed deliveries get a fresh attempt budget and are picked up by the outbox worker.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args)
+		},
+	}
+
+	return cmd
+}
+
+func (c *ResendCommand) run(cmd *cobra.Command, ids []string) error {
+	notificationOutbox, err := c.depResolver.NotificationOutbox()
+	if err != nil {
+		return fmt.Errorf("resolving notification outbox: %w", err)
+	}
+
+	for _, id := range ids {
+		count, err := notificationOutbox.Resend(cmd.Context(), id)
+		if err != nil {
+			return fmt.Errorf("re-sending notification %s: %w", id, err)
+		}
+
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s: %d deliveries re-queued\n", id, count)
+	}
+
+	return nil
+}
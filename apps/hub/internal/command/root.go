@@ -6,7 +6,11 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/abgeo/maroid/apps/hub/internal/command/cron"
+	"github.com/abgeo/maroid/apps/hub/internal/command/devices"
 	"github.com/abgeo/maroid/apps/hub/internal/command/migrate"
+	"github.com/abgeo/maroid/apps/hub/internal/command/mqtt"
+	"github.com/abgeo/maroid/apps/hub/internal/command/notifications"
 	"github.com/abgeo/maroid/apps/hub/internal/command/serve"
 	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
 	"github.com/abgeo/maroid/apps/hub/internal/registry"
@@ -25,7 +29,12 @@ func New(depResolver depresolver.Resolver) (*Command, error) {
 	}
 
 	err = commandRegistry.Register(
+		cron.New(depResolver).Command(),
+		devices.New(depResolver).Command(),
 		migrate.New(depResolver).Command(),
+		mqtt.New(depResolver).Command(),
+		notifications.New(depResolver).Command(),
+		NewRunCommand(depResolver).Command(),
 		serve.New(depResolver).Command(),
 		NewWorkerCommand(depResolver).Command(),
 	)
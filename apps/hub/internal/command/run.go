@@ -0,0 +1,179 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/abgeo/maroid/apps/hub/internal/command/serve"
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
+	"github.com/abgeo/maroid/apps/hub/internal/worker"
+)
+
+// RunCommand represents a command for running the HTTP server, the Telegram updates handler
+// and background workers in a single process.
+type RunCommand struct {
+	depResolver depresolver.Resolver
+	cfg         *config.Config
+	logger      *slog.Logger
+
+	selectedWorkers []string
+	supervisor      *worker.Supervisor
+}
+
+// NewRunCommand creates a new RunCommand.
+func NewRunCommand(depResolver depresolver.Resolver) *RunCommand {
+	return &RunCommand{
+		depResolver: depResolver,
+		cfg:         depResolver.Config(),
+		logger: depResolver.Logger().With(
+			slog.String("component", "command"),
+			slog.String("command", "run"),
+		),
+	}
+}
+
+// Command initializes and returns the Cobra command.
+func (c *RunCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run HTTP server, Telegram updates handler and background workers in one process",
+		Long: "Run the HTTP server, the Telegram updates handler and the selected background workers " +
+			"in a single process sharing one dependency graph. This replaces running `serve http` and " +
+			"`worker` separately; on shutdown the HTTP server stops first, then the Telegram updates " +
+			"handler, and finally the workers.",
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return c.prepare()
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.startServices(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(
+		&c.selectedWorkers,
+		"workers",
+		"w",
+		[]string{worker.AllWorkers},
+		"Worker types to run, comma-separated (e.g. --workers cron,mqtt,plugin:<id>:<name>) "+
+			"or 'all' to run all workers",
+	)
+
+	return cmd
+}
+
+func (c *RunCommand) prepare() error {
+	workers, err := c.depResolver.Workers()
+	if err != nil {
+		return fmt.Errorf("resolving workers: %w", err)
+	}
+
+	selected, err := worker.Select(workers, c.selectedWorkers)
+	if err != nil {
+		return err
+	}
+
+	c.supervisor = worker.NewSupervisor(c.cfg, c.logger, selected, workerShutdownTimeout)
+
+	if err = c.supervisor.Prepare(); err != nil {
+		return fmt.Errorf("preparing workers: %w", err)
+	}
+
+	return nil
+}
+
+// startServices runs all services until the context is cancelled or one of them fails.
+// Shutdown is ordered: the HTTP server stops accepting requests first, then the Telegram
+// updates handler drains, and finally the workers are stopped.
+func (c *RunCommand) startServices(ctx context.Context) error {
+	errGroup, groupCtx := errgroup.WithContext(ctx)
+
+	server, err := c.depResolver.HTTPServer()
+	if err != nil {
+		return fmt.Errorf("resolving HTTP server: %w", err)
+	}
+
+	telegramUpdatesHandler, err := c.depResolver.TelegramUpdatesHandler()
+	if err != nil {
+		return fmt.Errorf("resolving Telegram updates handler: %w", err)
+	}
+
+	// The status server outlives the workers so that their shutdown stays observable.
+	statusServer := startWorkerStatusServer(ctx, c.logger, c.cfg.Worker.StatusAddress, c.supervisor)
+
+	// Workers outlive the group context so that they are stopped only after the ingress.
+	workersCtx, stopWorkers := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopWorkers()
+
+	errGroup.Go(func() error {
+		c.logger.InfoContext(groupCtx, "starting HTTP server",
+			slog.String("address", c.cfg.Server.ListenAddr),
+			slog.String("port", c.cfg.Server.Port),
+		)
+
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("listening and serving: %w", err)
+		}
+
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		c.logger.InfoContext(
+			groupCtx,
+			"starting telegram updates handler",
+			slog.String("webhook", c.cfg.Telegram.Webhook.Path),
+		)
+
+		err := telegramUpdatesHandler.Handle(groupCtx)
+		if err != nil {
+			return fmt.Errorf("handling telegram updates: %w", err)
+		}
+
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		err := c.supervisor.Run(workersCtx)
+		if err != nil {
+			return fmt.Errorf("running workers: %w", err)
+		}
+
+		return nil
+	})
+
+	go func() {
+		<-groupCtx.Done()
+		c.logger.Info("termination signal received")
+
+		serve.ShutdownStep(ctx, c.logger, "shutting down HTTP server", server.Shutdown)
+		serve.ShutdownStep(
+			ctx,
+			c.logger,
+			"stopping telegram updates handler",
+			telegramUpdatesHandler.Stop,
+		)
+
+		c.logger.Info("stopping workers")
+		stopWorkers()
+	}()
+
+	err = errGroup.Wait()
+
+	if statusServer != nil {
+		serve.ShutdownStep(ctx, c.logger, "shutting down worker status server", statusServer.Shutdown)
+	}
+
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("services errored: %w", err)
+	}
+
+	return nil
+}
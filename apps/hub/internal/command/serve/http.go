@@ -103,8 +103,8 @@ func (c *HTTPCommand) startServices(ctx context.Context) error {
 		<-ctx.Done()
 		c.logger.Info("termination signal received")
 
-		c.shutdownStep(ctx, "stopping telegram updates handler", telegramUpdatesHandler.Stop)
-		c.shutdownStep(ctx, "shutting down HTTP server", server.Shutdown)
+		ShutdownStep(ctx, c.logger, "stopping telegram updates handler", telegramUpdatesHandler.Stop)
+		ShutdownStep(ctx, c.logger, "shutting down HTTP server", server.Shutdown)
 	}()
 
 	err = errGroup.Wait()
@@ -115,18 +115,21 @@ func (c *HTTPCommand) startServices(ctx context.Context) error {
 	return nil
 }
 
-func (c *HTTPCommand) shutdownStep(
+// ShutdownStep runs one step of a graceful shutdown, bounded by the shutdown timeout,
+// and logs its failure.
+func ShutdownStep(
 	ctx context.Context,
+	logger *slog.Logger,
 	title string,
 	step func(ctx context.Context) error,
 ) {
 	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
 	defer cancel()
 
-	c.logger.InfoContext(ctx, title)
+	logger.InfoContext(ctx, title)
 
 	if err := step(ctx); err != nil {
-		c.logger.ErrorContext(
+		logger.ErrorContext(
 			ctx,
 			"shutdown step failed",
 			slog.String("step", title),
@@ -2,18 +2,17 @@ package command
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"maps"
-	"slices"
-	"strings"
+	"net/http"
 	"time"
 
 	"github.com/spf13/cobra"
-	"golang.org/x/sync/errgroup"
 
+	"github.com/abgeo/maroid/apps/hub/internal/command/serve"
 	"github.com/abgeo/maroid/apps/hub/internal/depresolver"
-	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/handler"
 	"github.com/abgeo/maroid/apps/hub/internal/worker"
 )
 
@@ -25,7 +24,7 @@ type WorkerCommand struct {
 	logger      *slog.Logger
 
 	selectedWorkers []string
-	workers         []worker.Worker
+	supervisor      *worker.Supervisor
 }
 
 // NewWorkerCommand creates a new WorkerCommand.
@@ -56,130 +55,83 @@ func (c *WorkerCommand) Command() *cobra.Command {
 		&c.selectedWorkers,
 		"workers",
 		"w",
-		[]string{"all"},
-		"Worker types to run, comma-separated (e.g. --workers cron,mqtt) or 'all' to run all workers",
+		[]string{worker.AllWorkers},
+		"Worker types to run, comma-separated (e.g. --workers cron,mqtt,plugin:<id>:<name>) "+
+			"or 'all' to run all workers",
 	)
 
 	return cmd
 }
 
 func (c *WorkerCommand) prepare() error {
-	var err error
-
-	c.workers, err = c.getWorkers()
+	workers, err := c.depResolver.Workers()
 	if err != nil {
-		return err
+		return fmt.Errorf("resolving workers: %w", err)
 	}
 
-	if len(c.selectedWorkers) > 0 {
-		filtered, err := c.filterWorkers(c.selectedWorkers)
-		if err != nil {
-			return err
-		}
-
-		c.workers = filtered
+	selected, err := worker.Select(workers, c.selectedWorkers)
+	if err != nil {
+		return err
 	}
 
-	for _, w := range c.workers {
-		c.logger.Info("preparing worker", slog.String("worker", w.Name()))
+	c.supervisor = worker.NewSupervisor(
+		c.depResolver.Config(),
+		c.logger,
+		selected,
+		workerShutdownTimeout,
+	)
 
-		if err := w.Prepare(); err != nil {
-			return fmt.Errorf("preparing worker %s: %w", w.Name(), err)
-		}
+	if err = c.supervisor.Prepare(); err != nil {
+		return fmt.Errorf("preparing workers: %w", err)
 	}
 
 	return nil
 }
 
-func (c *WorkerCommand) getWorkers() ([]worker.Worker, error) {
-	cfg := c.depResolver.Config()
-	cronScheduler := c.depResolver.Cron()
+func (c *WorkerCommand) run(ctx context.Context) error {
+	statusServer := startWorkerStatusServer(
+		ctx,
+		c.logger,
+		c.depResolver.Config().Worker.StatusAddress,
+		c.supervisor,
+	)
 
-	cronRegistry, err := c.depResolver.CronRegistry()
-	if err != nil {
-		return nil, fmt.Errorf("resolving cron registry: %w", err)
+	err := c.supervisor.Run(ctx)
+
+	if statusServer != nil {
+		serve.ShutdownStep(ctx, c.logger, "shutting down worker status server", statusServer.Shutdown)
 	}
 
-	mqttSubscriberRegistry, err := c.depResolver.MQTTSubscriberRegistry()
 	if err != nil {
-		return nil, fmt.Errorf("resolving MQTT subscriber registry: %w", err)
+		return fmt.Errorf("running workers: %w", err)
 	}
 
-	return []worker.Worker{
-		worker.NewCronWorker(c.logger, cronScheduler, cronRegistry),
-		worker.NewMQTTWorker(c.logger, cfg, mqttSubscriberRegistry),
-	}, nil
+	return nil
 }
 
-func (c *WorkerCommand) filterWorkers(names []string) ([]worker.Worker, error) {
-	if slices.Contains(names, "all") {
-		return c.workers, nil
+// startWorkerStatusServer serves the worker states on address in the background and returns
+// the server, or nil if address is empty. The route is not authenticated, so the states are
+// served on this separate listener only.
+func startWorkerStatusServer(
+	ctx context.Context,
+	logger *slog.Logger,
+	address string,
+	provider handler.WorkerStatusProvider,
+) *http.Server {
+	if address == "" {
+		return nil
 	}
 
-	result := make([]worker.Worker, 0, len(names))
-	index := make(map[string]worker.Worker, len(c.workers))
-
-	for _, wrk := range c.workers {
-		index[wrk.Name()] = wrk
-	}
-
-	for _, name := range names {
-		wrk, ok := index[name]
-		if !ok {
-			return nil, fmt.Errorf(
-				"%w: %q (available: %s)",
-				errs.ErrUnknownWorkerType,
-				name,
-				strings.Join(slices.Collect(maps.Keys(index)), ", "),
-			)
-		}
-
-		result = append(result, wrk)
-	}
-
-	return result, nil
-}
-
-func (c *WorkerCommand) run(ctx context.Context) error {
-	errGroup, ctx := errgroup.WithContext(ctx)
-
-	for _, wrk := range c.workers {
-		c.logger.InfoContext(ctx, "starting worker", slog.String("worker", wrk.Name()))
-
-		errGroup.Go(func() error {
-			if err := wrk.Start(ctx); err != nil {
-				return fmt.Errorf("starting worker %s: %w", wrk.Name(), err)
-			}
-
-			return nil
-		})
-	}
+	server := handler.NewWorkerStatusServer(logger, address, provider)
 
 	go func() {
-		<-ctx.Done()
-		c.logger.Info("termination signal received, shutting down workers")
-
-		shutdownCtx, cancel := context.WithTimeout(
-			context.WithoutCancel(ctx),
-			workerShutdownTimeout,
-		)
-		defer cancel()
-
-		for _, wrk := range c.workers {
-			if err := wrk.Stop(shutdownCtx); err != nil {
-				c.logger.ErrorContext(
-					shutdownCtx,
-					"worker stop failed",
-					slog.String("worker", wrk.Name()),
-					slog.Any("error", err),
-				)
-			}
+		logger.InfoContext(ctx, "starting worker status server", slog.String("address", address))
+
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.ErrorContext(ctx, "worker status server failed", slog.Any("error", err))
 		}
 	}()
 
-	if err := errGroup.Wait(); err != nil {
-		return fmt.Errorf("worker error: %w", err)
-	}
-
-	return nil
+	return server
 }
@@ -98,6 +98,7 @@ type OIDC struct {
 
 // MQTT defines MQTT broker configuration parameters.
 // All fields are optional; the broker is only required when MQTT subscriber plugins are loaded.
+// Version selects the protocol: 3 for MQTT v3.1.1 or 5 for MQTT v5.
 type MQTT struct {
 	Broker            string
 	User              string
@@ -106,6 +107,198 @@ type MQTT struct {
 	SharedGroup       string        `default:"maroid" mapstructure:"shared_group"`
 	ConnectTimeout    time.Duration `default:"5s"     mapstructure:"connect_timeout"`
 	DisconnectQuiesce uint          `default:"250"    mapstructure:"disconnect_quiesce"`
+	Version           int           `default:"3"      mapstructure:"version"            validate:"oneof=3 5"`
+	TLS               MQTTTLS
+	V5                MQTTV5 `mapstructure:"v5"`
+	Dispatch          MQTTDispatch
+	DeadLetter        MQTTDeadLetter `mapstructure:"dead_letter"`
+	Embedded          MQTTEmbedded
+	EMQX              MQTTEMQX `mapstructure:"emqx"`
+}
+
+// HomeAssistant defines the bridge that exposes plugin entities to Home Assistant over MQTT.
+// Discovery configs are published retained under DiscoveryPrefix and again whenever Home Assistant
+// announces itself online on StatusTopic. NodeID groups the discovery topics and unique IDs of this hub.
+// Entity states are polled every StateInterval unless an entity declares its own interval.
+type HomeAssistant struct {
+	Enabled         bool          `default:"false"                mapstructure:"enabled"`
+	DiscoveryPrefix string        `default:"homeassistant"        mapstructure:"discovery_prefix" validate:"required"`
+	StatusTopic     string        `default:"homeassistant/status" mapstructure:"status_topic"     validate:"required"`
+	NodeID          string        `default:"maroid"               mapstructure:"node_id"          validate:"required"`
+	StateInterval   time.Duration `default:"60s"                  mapstructure:"state_interval"   validate:"gt=0"`
+	CommandTimeout  time.Duration `default:"30s"                  mapstructure:"command_timeout"  validate:"gt=0"`
+}
+
+// MQTTEMQX defines the HTTP authentication and authorization hooks EMQX calls to check devices.
+// The hooks are served only when HookToken is set, and EMQX must send it as a bearer token.
+type MQTTEMQX struct {
+	HookToken string `mapstructure:"hook_token"`
+}
+
+// MQTTEmbedded defines the broker the MQTT worker runs in-process for single-node deployments.
+// The worker and the plugin MQTT publisher then connect to it in-process; without Broker,
+// other processes, e.g. the CLI, connect to its first TCP listener on localhost.
+// Without Listeners, a plain TCP listener on :1883 is started. Clients authenticate as one of Users
+// and may only publish and subscribe to the topic filters of that user.
+// The grants of connected clients are re-read every GrantRefreshInterval, so that revoked devices
+// are disconnected and changed topic filters apply to live connections.
+type MQTTEmbedded struct {
+	Enabled              bool               `default:"false" mapstructure:"enabled"`
+	Listeners            []MQTTListener     `                mapstructure:"listeners"              validate:"dive"`
+	Users                []MQTTEmbeddedUser `                mapstructure:"users"                  validate:"dive"`
+	GrantRefreshInterval time.Duration      `default:"30s"   mapstructure:"grant_refresh_interval" validate:"gt=0"`
+}
+
+// MQTT listener types.
+const (
+	MQTTListenerTCP       = "tcp"
+	MQTTListenerWebSocket = "websocket"
+)
+
+// MQTTDefaultListenerAddress is the address of the embedded broker listener started without Listeners.
+const MQTTDefaultListenerAddress = ":1883"
+
+// MQTTListener defines a network listener of the embedded broker; Type defaults to tcp.
+// The listener serves TLS when CertFile and KeyFile are set.
+type MQTTListener struct {
+	Type     string `mapstructure:"type"      validate:"omitempty,oneof=tcp websocket"`
+	Address  string `mapstructure:"address"   validate:"required"`
+	CertFile string `mapstructure:"cert_file" validate:"required_with=KeyFile,omitempty,file"`
+	KeyFile  string `mapstructure:"key_file"  validate:"required_with=CertFile,omitempty,file"`
+}
+
+// MQTTEmbeddedUser defines a client of the embedded broker.
+// Topics are absolute topic filters and may contain the + and # wildcards.
+type MQTTEmbeddedUser struct {
+	Username string   `mapstructure:"username" validate:"required"`
+	Password string   `mapstructure:"password" validate:"required"`
+	Topics   []string `mapstructure:"topics"`
+}
+
+// MQTTDeadLetter defines whether messages subscribers fail to handle are persisted for replay.
+type MQTTDeadLetter struct {
+	Enabled bool `default:"true" mapstructure:"enabled"`
+}
+
+// MQTTTLS defines TLS options of the MQTT broker connection.
+// They apply when the broker URL uses a TLS scheme (e.g. tls://, ssl:// or mqtts://).
+// InsecureSkipVerify disables server certificate verification and is meant for development only.
+type MQTTTLS struct {
+	CAFile             string `                mapstructure:"ca_file"              validate:"omitempty,file"`
+	CertFile           string `                mapstructure:"cert_file"            validate:"required_with=KeyFile,omitempty,file"`
+	KeyFile            string `                mapstructure:"key_file"             validate:"required_with=CertFile,omitempty,file"`
+	ServerName         string `                mapstructure:"server_name"`
+	InsecureSkipVerify bool   `default:"false" mapstructure:"insecure_skip_verify"`
+}
+
+// MQTTV5 defines options that only apply with MQTT v5.
+// SessionExpiry keeps the broker session (and its subscriptions) across reconnects; zero ends it
+// with the connection. UserProperties are sent with the connection and every published message;
+// published messages expire after MessageExpiry, zero meaning never.
+type MQTTV5 struct {
+	SessionExpiry  time.Duration     `default:"0s" mapstructure:"session_expiry"  validate:"min=0"`
+	MessageExpiry  time.Duration     `default:"0s" mapstructure:"message_expiry"  validate:"min=0"`
+	UserProperties map[string]string `             mapstructure:"user_properties"`
+}
+
+// MQTT protocol versions.
+const (
+	MQTTVersion3 = 3
+	MQTTVersion5 = 5
+)
+
+// MQTT dispatch overflow policies.
+const (
+	MQTTOverflowBlock      = "block"
+	MQTTOverflowDropNewest = "drop_newest"
+	MQTTOverflowDropOldest = "drop_oldest"
+)
+
+// MQTTDispatch defines how incoming MQTT messages are handed to subscribers.
+// Every subscriber gets its own pool of Concurrency handlers fed by a queue of QueueDepth messages;
+// OverflowPolicy decides what happens when the queue is full. With Ordered set, messages of the same
+// topic are handled one at a time in arrival order.
+type MQTTDispatch struct {
+	Concurrency    int           `default:"4"     mapstructure:"concurrency"     validate:"min=1"`
+	QueueDepth     int           `default:"100"   mapstructure:"queue_depth"     validate:"min=1"`
+	OverflowPolicy string        `default:"block" mapstructure:"overflow_policy" validate:"oneof=block drop_newest drop_oldest"`
+	Ordered        bool          `default:"false" mapstructure:"ordered"`
+	HandlerTimeout time.Duration `default:"30s"   mapstructure:"handler_timeout" validate:"min=0"`
+}
+
+// CronAlerts defines the alerting policy for failing cron jobs.
+// Alerts are disabled when Channel is empty.
+type CronAlerts struct {
+	Channel          string        `             mapstructure:"channel"`
+	FailureThreshold int           `default:"1"  mapstructure:"failure_threshold" validate:"min=1"`
+	RepeatInterval   time.Duration `default:"0s" mapstructure:"repeat_interval"`
+}
+
+// Cron defines cron scheduler configuration parameters.
+// Timezone is the IANA time zone schedules are evaluated in; empty means host local time.
+type Cron struct {
+	Timezone string `mapstructure:"timezone" validate:"omitempty,timezone"`
+	Alerts   CronAlerts
+}
+
+// Scheduler defines configuration parameters of the runtime task scheduler.
+// LeaseDuration bounds how long a claimed task stays locked before another worker may pick it up.
+// Failed one-off tasks are retried up to MaxAttempts runs in total; retry delays start at Backoff
+// and double with every attempt up to MaxBackoff.
+type Scheduler struct {
+	PollInterval  time.Duration `default:"5s"  mapstructure:"poll_interval"  validate:"gt=0"`
+	BatchSize     int           `default:"10"  mapstructure:"batch_size"     validate:"min=1"`
+	LeaseDuration time.Duration `default:"10m" mapstructure:"lease_duration" validate:"gt=0"`
+	MaxAttempts   int           `default:"5"   mapstructure:"max_attempts"   validate:"min=1"`
+	Backoff       time.Duration `default:"10s" mapstructure:"backoff"        validate:"gt=0"`
+	MaxBackoff    time.Duration `default:"1h"  mapstructure:"max_backoff"    validate:"gt=0"`
+}
+
+// Queue defines configuration parameters of the background task queue.
+// The defaults apply to tasks enqueued without explicit options; retry delays double
+// with every attempt up to MaxBackoff.
+type Queue struct {
+	PollInterval       time.Duration `default:"1s"  mapstructure:"poll_interval"        validate:"gt=0"`
+	Concurrency        int           `default:"4"   mapstructure:"concurrency"          validate:"min=1"`
+	LeaseDuration      time.Duration `default:"10m" mapstructure:"lease_duration"       validate:"gt=0"`
+	DefaultMaxAttempts int           `default:"5"   mapstructure:"default_max_attempts" validate:"min=1"`
+	DefaultBackoff     time.Duration `default:"10s" mapstructure:"default_backoff"      validate:"gt=0"`
+	MaxBackoff         time.Duration `default:"1h"  mapstructure:"max_backoff"          validate:"gt=0"`
+}
+
+// NotifierOutbox defines the durable notification outbox.
+// When enabled, notifications are persisted and delivered by the outbox worker, which retries
+// every transport of the channel separately; retry delays double with every attempt up to MaxBackoff.
+// Fallback transports are tried once all primary transports of a message failed permanently.
+// DeliveryTimeout bounds every delivery attempt; it must be shorter than LeaseDuration so that
+// a hanging transport gives up before the delivery can be claimed again.
+type NotifierOutbox struct {
+	Enabled         bool          `default:"false" mapstructure:"enabled"`
+	PollInterval    time.Duration `default:"1s"    mapstructure:"poll_interval"    validate:"gt=0"`
+	Concurrency     int           `default:"4"     mapstructure:"concurrency"      validate:"min=1"`
+	LeaseDuration   time.Duration `default:"5m"    mapstructure:"lease_duration"   validate:"gt=0"`
+	DeliveryTimeout time.Duration `default:"1m"    mapstructure:"delivery_timeout" validate:"gt=0,ltfield=LeaseDuration"`
+	MaxAttempts     int           `default:"10"    mapstructure:"max_attempts"     validate:"min=1"`
+	Backoff         time.Duration `default:"30s"   mapstructure:"backoff"          validate:"gt=0"`
+	MaxBackoff      time.Duration `default:"1h"    mapstructure:"max_backoff"      validate:"gt=0"`
+}
+
+// WorkerSupervisor defines how failed workers are restarted.
+// A worker is restarted with exponential backoff; once it fails MaxRestarts times within
+// RestartWindow it is marked as failed, which stops the process only if ExitOnFailure is set.
+type WorkerSupervisor struct {
+	InitialBackoff time.Duration `default:"1s"    mapstructure:"initial_backoff" validate:"gt=0"`
+	MaxBackoff     time.Duration `default:"1m"    mapstructure:"max_backoff"     validate:"gt=0"`
+	MaxRestarts    int           `default:"5"     mapstructure:"max_restarts"    validate:"min=0"`
+	RestartWindow  time.Duration `default:"10m"   mapstructure:"restart_window"  validate:"gt=0"`
+	ExitOnFailure  bool          `default:"false" mapstructure:"exit_on_failure"`
+}
+
+// Worker defines background worker configuration parameters.
+// When StatusAddress is set, the worker process serves worker states on it.
+type Worker struct {
+	StatusAddress string `mapstructure:"status_address" validate:"omitempty,hostname_port"`
+	Supervisor    WorkerSupervisor
 }
 
 // Telegram defines Telegram integration configuration parameters.
@@ -124,17 +317,23 @@ type Telegram struct {
 type Config struct {
 	Env string `default:"prod" validate:"oneof=dev prod"`
 
-	Logger   Logger
-	Database Database
-	Server   Server
-	CORS     CORS
-	JWT      JWT
-	Auth     Auth
-	OIDC     OIDC
-	MQTT     MQTT
-	Telegram Telegram
-	Notifier notifier.Config
-	Plugins  []pluginconfig.Config
+	Logger         Logger
+	Database       Database
+	Server         Server
+	CORS           CORS
+	JWT            JWT
+	Auth           Auth
+	OIDC           OIDC
+	MQTT           MQTT
+	HomeAssistant  HomeAssistant `mapstructure:"home_assistant"`
+	Cron           Cron
+	Scheduler      Scheduler
+	Queue          Queue
+	Worker         Worker
+	Telegram       Telegram
+	Notifier       notifier.Config
+	NotifierOutbox NotifierOutbox `mapstructure:"notifier_outbox"`
+	Plugins        []pluginconfig.Config
 }
 
 // New loads configuration from the given file path or environment variables.
@@ -0,0 +1,89 @@
+// Package cronrun executes registered cron jobs on demand, optionally in dry-run mode.
+package cronrun
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// Result describes a single manual cron job execution.
+type Result struct {
+	JobID     string
+	DryRun    bool
+	StartedAt time.Time
+	Duration  time.Duration
+	// Err is the error returned by the job, if any.
+	Err error
+	// Notifications holds the messages recorded instead of being sent during a dry run.
+	Notifications []pluginapi.DryRunNotification
+}
+
+// Runner executes cron jobs outside of the scheduler.
+type Runner struct {
+	logger       *slog.Logger
+	cronRegistry *registry.CronRegistry
+}
+
+// NewRunner creates a new Runner.
+func NewRunner(logger *slog.Logger, cronRegistry *registry.CronRegistry) *Runner {
+	return &Runner{
+		logger: logger.With(
+			slog.String("component", "cronrun"),
+		),
+		cronRegistry: cronRegistry,
+	}
+}
+
+// Run executes the job with the given ID once and waits for it to finish.
+// In dry-run mode the job runs with a dry-run context: database transactions opened
+// through PluginDB are rolled back, notifications are recorded and Telegram calls are logged.
+// It returns an error only if the job cannot be found; job failures are reported in the Result.
+func (r *Runner) Run(ctx context.Context, jobID string, dryRun bool) (*Result, error) {
+	job, ok := r.cronRegistry.Get(jobID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errs.ErrCronJobNotFound, jobID)
+	}
+
+	var recorder *pluginapi.DryRun
+	if dryRun {
+		ctx, recorder = pluginapi.WithDryRun(ctx)
+	}
+
+	logger := r.logger.With(
+		slog.String("job_id", jobID),
+		slog.Bool("dry_run", dryRun),
+	)
+
+	result := &Result{
+		JobID:     jobID,
+		DryRun:    dryRun,
+		StartedAt: time.Now(),
+	}
+
+	logger.InfoContext(ctx, "manual cron job execution started")
+
+	result.Err = job.Run(ctx)
+	result.Duration = time.Since(result.StartedAt)
+
+	if recorder != nil {
+		result.Notifications = recorder.Notifications()
+	}
+
+	if result.Err != nil {
+		logger.ErrorContext(ctx, "manual cron job execution failed", slog.Any("error", result.Err))
+
+		return result, nil
+	}
+
+	logger.InfoContext(ctx, "manual cron job execution completed successfully",
+		slog.Duration("duration", result.Duration),
+	)
+
+	return result, nil
+}
@@ -0,0 +1,197 @@
+// Package cronschedule parses cron job schedules with timezone support
+// and computes their upcoming runs.
+package cronschedule
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+var timezonePrefixes = []string{"CRON_TZ=", "TZ="}
+
+// Schedule is a parsed cron schedule bound to a time zone.
+type Schedule struct {
+	// Spec is the effective spec including the CRON_TZ= prefix.
+	Spec string
+	// Location is the time zone the schedule is evaluated in.
+	Location *time.Location
+
+	schedule cron.Schedule
+}
+
+var _ cron.Schedule = (*Schedule)(nil)
+
+// Next returns the next activation time after t, expressed in the schedule time zone.
+func (s *Schedule) Next(t time.Time) time.Time {
+	return s.schedule.Next(t.In(s.Location)).In(s.Location)
+}
+
+// NextN returns the next n activation times after t.
+func (s *Schedule) NextN(t time.Time, n int) []time.Time {
+	runs := make([]time.Time, 0, n)
+
+	for range n {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+
+		runs = append(runs, t)
+	}
+
+	return runs
+}
+
+// Parser parses cron job schedules. It accepts 5-field specs, 6-field specs with
+// a leading seconds field, descriptors (e.g. @monthly, @every 1h) and CRON_TZ= prefixes.
+//
+// The time zone of a schedule is resolved in the following order:
+// the CRON_TZ= (or TZ=) prefix of the spec, the job Timezone metadata,
+// and finally the default location of the parser.
+type Parser struct {
+	parser   cron.Parser
+	location *time.Location
+}
+
+// NewParser creates a new Parser with the given default time zone.
+// An empty time zone falls back to the host local time.
+func NewParser(timezone string) (*Parser, error) {
+	location, err := loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parser{
+		parser: cron.NewParser(
+			cron.SecondOptional |
+				cron.Minute |
+				cron.Hour |
+				cron.Dom |
+				cron.Month |
+				cron.Dow |
+				cron.Descriptor,
+		),
+		location: location,
+	}, nil
+}
+
+// Location returns the default time zone of the parser.
+func (p *Parser) Location() *time.Location {
+	return p.location
+}
+
+// Parse parses the schedule of a cron job.
+func (p *Parser) Parse(meta pluginapi.CronJobMeta) (*Schedule, error) {
+	spec := strings.TrimSpace(meta.Schedule)
+
+	location, hasPrefix, err := timezoneFromSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasPrefix {
+		location = p.location
+
+		if meta.Timezone != "" {
+			location, err = loadLocation(meta.Timezone)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		spec = fmt.Sprintf("CRON_TZ=%s %s", location, spec)
+	}
+
+	schedule, err := p.parser.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cron schedule %q: %w", meta.Schedule, err)
+	}
+
+	return &Schedule{
+		Spec:     spec,
+		Location: location,
+		schedule: schedule,
+	}, nil
+}
+
+func timezoneFromSpec(spec string) (*time.Location, bool, error) {
+	for _, prefix := range timezonePrefixes {
+		rest, found := strings.CutPrefix(spec, prefix)
+		if !found {
+			continue
+		}
+
+		name, _, found := strings.Cut(rest, " ")
+		if !found {
+			return nil, false, fmt.Errorf("parsing cron schedule %q: missing spec after time zone", spec)
+		}
+
+		location, err := loadLocation(name)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return location, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("loading time zone %q: %w", name, err)
+	}
+
+	return location, nil
+}
+
+// Preview describes a cron job schedule and its upcoming runs.
+type Preview struct {
+	JobID    string      `json:"id"`
+	Schedule string      `json:"schedule"`
+	Timezone string      `json:"timezone"`
+	NextRuns []time.Time `json:"next_runs"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// Previews builds previews of the next n runs after now for each job, sorted by job ID.
+// Jobs with invalid schedules are included with the parse error set.
+func (p *Parser) Previews(jobs []pluginapi.CronJob, now time.Time, n int) []Preview {
+	previews := make([]Preview, 0, len(jobs))
+
+	for _, job := range jobs {
+		meta := job.Meta()
+		preview := Preview{
+			JobID:    meta.ID,
+			Schedule: meta.Schedule,
+			NextRuns: []time.Time{},
+		}
+
+		schedule, err := p.Parse(meta)
+		if err != nil {
+			preview.Error = err.Error()
+		} else {
+			preview.Timezone = schedule.Location.String()
+			preview.NextRuns = schedule.NextN(now, n)
+		}
+
+		previews = append(previews, preview)
+	}
+
+	slices.SortFunc(previews, func(a, b Preview) int {
+		return strings.Compare(a.JobID, b.JobID)
+	})
+
+	return previews
+}
@@ -3,12 +3,14 @@
 package database
 
 import (
+	"context"
 	"fmt"
 
 	_ "github.com/jackc/pgx/stdlib" // The PostgreSQL driver for sqlx
 	"github.com/jmoiron/sqlx"
 
 	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/libs/pluginapi"
 )
 
 // New creates and returns a new PostgreSQL connection using the provided configuration.
@@ -21,3 +23,30 @@ func New(cfg *config.Config) (*sqlx.DB, error) {
 
 	return db, nil
 }
+
+// WithTx executes a function within a database transaction.
+// The transaction is committed if fn returns nil and rolled back otherwise.
+// In dry-run mode (see pluginapi.WithDryRun) the transaction is always rolled back.
+func WithTx(ctx context.Context, db *sqlx.DB, fn func(*sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if pluginapi.IsDryRun(ctx) {
+		return nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
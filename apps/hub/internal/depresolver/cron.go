@@ -1,26 +1,61 @@
 package depresolver
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/robfig/cron/v3"
 
+	"github.com/abgeo/maroid/apps/hub/internal/alert"
+	"github.com/abgeo/maroid/apps/hub/internal/cronschedule"
 	"github.com/abgeo/maroid/apps/hub/internal/registry"
 )
 
 // Cron initializes and returns the cron scheduler instance.
-func (c *Container) Cron() *cron.Cron {
+func (c *Container) Cron() (*cron.Cron, error) {
+	c.cron.mu.Lock()
+	defer c.cron.mu.Unlock()
+
+	var err error
+
 	c.cron.once.Do(func() {
-		parser := cron.NewParser(
-			cron.SecondOptional |
-				cron.Minute |
-				cron.Hour |
-				cron.Dom |
-				cron.Month |
-				cron.Dow,
-		)
-		c.cron.instance = cron.New(cron.WithParser(parser))
+		parser, parserErr := c.CronScheduleParser()
+		if parserErr != nil {
+			err = parserErr
+
+			return
+		}
+
+		c.cron.instance = cron.New(cron.WithLocation(parser.Location()))
 	})
 
-	return c.cron.instance
+	if err != nil {
+		c.cron.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing cron scheduler: %w", err)
+	}
+
+	return c.cron.instance, nil
+}
+
+// CronScheduleParser initializes and returns the cron schedule parser instance.
+func (c *Container) CronScheduleParser() (*cronschedule.Parser, error) {
+	c.cronScheduleParser.mu.Lock()
+	defer c.cronScheduleParser.mu.Unlock()
+
+	var err error
+
+	c.cronScheduleParser.once.Do(func() {
+		c.cronScheduleParser.instance, err = cronschedule.NewParser(c.Config().Cron.Timezone)
+	})
+
+	if err != nil {
+		c.cronScheduleParser.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing cron schedule parser: %w", err)
+	}
+
+	return c.cronScheduleParser.instance, nil
 }
 
 // CronRegistry initializes and returns the cron registry instance.
@@ -31,3 +66,42 @@ func (c *Container) CronRegistry() (*registry.CronRegistry, error) {
 
 	return c.cronRegistry.instance, nil
 }
+
+// CronAlertPolicy initializes and returns the cron job alerting policy instance.
+func (c *Container) CronAlertPolicy() (*alert.CronPolicy, error) {
+	c.cronAlertPolicy.mu.Lock()
+	defer c.cronAlertPolicy.mu.Unlock()
+
+	var err error
+
+	c.cronAlertPolicy.once.Do(func() {
+		db, dbErr := c.Database()
+		if dbErr != nil {
+			err = dbErr
+
+			return
+		}
+
+		notifier, notifierErr := c.Notifier()
+		if notifierErr != nil {
+			err = notifierErr
+
+			return
+		}
+
+		c.cronAlertPolicy.instance, err = alert.NewCronPolicy(
+			c.Config(),
+			c.Logger(),
+			db,
+			notifier,
+		)
+	})
+
+	if err != nil {
+		c.cronAlertPolicy.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing cron alert policy: %w", err)
+	}
+
+	return c.cronAlertPolicy.instance, nil
+}
@@ -0,0 +1,35 @@
+package depresolver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/abgeo/maroid/apps/hub/internal/device"
+)
+
+// DeviceRegistry initializes and returns the device registry instance.
+func (c *Container) DeviceRegistry() (*device.Registry, error) {
+	c.deviceRegistry.mu.Lock()
+	defer c.deviceRegistry.mu.Unlock()
+
+	var err error
+
+	c.deviceRegistry.once.Do(func() {
+		db, dbErr := c.Database()
+		if dbErr != nil {
+			err = dbErr
+
+			return
+		}
+
+		c.deviceRegistry.instance = device.New(c.Logger(), db)
+	})
+
+	if err != nil {
+		c.deviceRegistry.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing device registry: %w", err)
+	}
+
+	return c.deviceRegistry.instance, nil
+}
@@ -0,0 +1,35 @@
+package depresolver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/abgeo/maroid/apps/hub/internal/homeassistant"
+)
+
+// HomeAssistantBridge initializes and returns the Home Assistant bridge instance.
+func (c *Container) HomeAssistantBridge() (*homeassistant.Bridge, error) {
+	c.homeAssistantBridge.mu.Lock()
+	defer c.homeAssistantBridge.mu.Unlock()
+
+	var err error
+
+	c.homeAssistantBridge.once.Do(func() {
+		entityRegistry, registryErr := c.HomeAssistantEntityRegistry()
+		if registryErr != nil {
+			err = registryErr
+
+			return
+		}
+
+		c.homeAssistantBridge.instance = homeassistant.New(c.Config(), c.Logger(), entityRegistry)
+	})
+
+	if err != nil {
+		c.homeAssistantBridge.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing Home Assistant bridge: %w", err)
+	}
+
+	return c.homeAssistantBridge.instance, nil
+}
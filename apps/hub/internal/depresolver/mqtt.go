@@ -1,6 +1,12 @@
 package depresolver
 
 import (
+	"fmt"
+	"sync"
+
+	"github.com/abgeo/maroid/apps/hub/internal/mqttbroker"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttdlq"
 	"github.com/abgeo/maroid/apps/hub/internal/registry"
 )
 
@@ -12,3 +18,128 @@ func (c *Container) MQTTSubscriberRegistry() (*registry.MQTTSubscriberRegistry,
 
 	return c.mqttSubscriberRegistry.instance, nil
 }
+
+// MQTTDeadLetterStore initializes and returns the MQTT dead-letter store instance.
+func (c *Container) MQTTDeadLetterStore() (*mqttdlq.Store, error) {
+	c.mqttDeadLetterStore.mu.Lock()
+	defer c.mqttDeadLetterStore.mu.Unlock()
+
+	var err error
+
+	c.mqttDeadLetterStore.once.Do(func() {
+		db, dbErr := c.Database()
+		if dbErr != nil {
+			err = dbErr
+
+			return
+		}
+
+		mqttSubscriberRegistry, registryErr := c.MQTTSubscriberRegistry()
+		if registryErr != nil {
+			err = registryErr
+
+			return
+		}
+
+		c.mqttDeadLetterStore.instance = mqttdlq.New(
+			c.Config(),
+			c.Logger(),
+			db,
+			mqttSubscriberRegistry,
+		)
+	})
+
+	if err != nil {
+		c.mqttDeadLetterStore.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing MQTT dead-letter store: %w", err)
+	}
+
+	return c.mqttDeadLetterStore.instance, nil
+}
+
+// MQTTPublisher initializes and returns the MQTT publisher instance.
+// The broker connection is established on first publish; with the embedded broker enabled,
+// messages are published to it in-process while it runs in this process.
+func (c *Container) MQTTPublisher() (*mqttclient.Publisher, error) {
+	c.mqttPublisher.mu.Lock()
+	defer c.mqttPublisher.mu.Unlock()
+
+	var err error
+
+	c.mqttPublisher.once.Do(func() {
+		cfg := c.Config()
+
+		var broker mqttclient.InlineBroker
+
+		if cfg.MQTT.Embedded.Enabled {
+			mqttBroker, brokerErr := c.MQTTBroker()
+			if brokerErr != nil {
+				err = brokerErr
+
+				return
+			}
+
+			broker = mqttBroker
+		}
+
+		c.mqttPublisher.instance = mqttclient.NewPublisher(cfg, c.Logger(), broker)
+	})
+
+	if err != nil {
+		c.mqttPublisher.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing MQTT publisher: %w", err)
+	}
+
+	return c.mqttPublisher.instance, nil
+}
+
+// CloseMQTTPublisher disconnects the MQTT publisher from the broker.
+func (c *Container) CloseMQTTPublisher() error {
+	if c.mqttPublisher.instance == nil {
+		return nil
+	}
+
+	c.mqttPublisher.instance.Close()
+
+	return nil
+}
+
+// MQTTBroker initializes and returns the embedded MQTT broker instance.
+// Clients authenticate as a configured user or as a registered device.
+// The broker is started by the MQTT worker.
+func (c *Container) MQTTBroker() (*mqttbroker.Broker, error) {
+	c.mqttBroker.mu.Lock()
+	defer c.mqttBroker.mu.Unlock()
+
+	var err error
+
+	c.mqttBroker.once.Do(func() {
+		cfg := c.Config()
+
+		deviceRegistry, registryErr := c.DeviceRegistry()
+		if registryErr != nil {
+			err = registryErr
+
+			return
+		}
+
+		c.mqttBroker.instance = mqttbroker.New(
+			cfg,
+			c.Logger(),
+			mqttbroker.CredentialChain{
+				mqttbroker.NewStaticCredentials(cfg),
+				deviceRegistry,
+			},
+		)
+	})
+
+	if err != nil {
+		c.mqttBroker.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing MQTT broker: %w", err)
+	}
+
+	return c.mqttBroker.instance, nil
+}
@@ -4,9 +4,17 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/abgeo/maroid/apps/hub/internal/outbox"
+
 	"github.com/abgeo/maroid/libs/notifier/dispatcher"
 	"github.com/abgeo/maroid/libs/notifier/registry"
+	"github.com/abgeo/maroid/libs/notifier/transport/discord"
+	"github.com/abgeo/maroid/libs/notifier/transport/matrix"
+	"github.com/abgeo/maroid/libs/notifier/transport/slack"
+	"github.com/abgeo/maroid/libs/notifier/transport/smtp"
 	"github.com/abgeo/maroid/libs/notifier/transport/telegram"
+	"github.com/abgeo/maroid/libs/notifier/transport/webhook"
+	"github.com/abgeo/maroid/libs/notifierapi"
 )
 
 // NotifierRegistry initializes and returns the notifier registry instance.
@@ -61,9 +69,60 @@ func (c *Container) NotifierDispatcher() (*dispatcher.ChannelDispatcher, error)
 	return c.notifierDispatcher.instance, nil
 }
 
+// NotificationOutbox initializes and returns the notification outbox instance.
+func (c *Container) NotificationOutbox() (*outbox.Outbox, error) {
+	c.notificationOutbox.mu.Lock()
+	defer c.notificationOutbox.mu.Unlock()
+
+	var err error
+
+	c.notificationOutbox.once.Do(func() {
+		db, dbErr := c.Database()
+		if dbErr != nil {
+			err = dbErr
+
+			return
+		}
+
+		channelDispatcher, dispatcherErr := c.NotifierDispatcher()
+		if dispatcherErr != nil {
+			err = dispatcherErr
+
+			return
+		}
+
+		c.notificationOutbox.instance = outbox.New(c.Config(), c.Logger(), db, channelDispatcher)
+	})
+
+	if err != nil {
+		c.notificationOutbox.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing notification outbox: %w", err)
+	}
+
+	return c.notificationOutbox.instance, nil
+}
+
+// Notifier returns the dispatcher notifications are sent through:
+// the notification outbox when it is enabled and the channel dispatcher otherwise.
+//
+//nolint:ireturn
+func (c *Container) Notifier() (notifierapi.Dispatcher, error) {
+	if c.Config().NotifierOutbox.Enabled {
+		return c.NotificationOutbox()
+	}
+
+	return c.NotifierDispatcher()
+}
+
 func registerNotifiers(reg registry.Registry) error {
 	registrations := []func(registry.Registry) error{
 		telegram.Register,
+		smtp.Register,
+		webhook.Register,
+		matrix.Register,
+		discord.Register,
+		slack.Register,
 	}
 
 	for i, register := range registrations {
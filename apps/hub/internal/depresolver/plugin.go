@@ -33,7 +33,7 @@ func (c *Container) PluginHost() (*pluginhost.Host, error) {
 			return
 		}
 
-		notifier, notifierErr := c.NotifierDispatcher()
+		notifier, notifierErr := c.Notifier()
 		if notifierErr != nil {
 			err = notifierErr
 
@@ -54,12 +54,36 @@ func (c *Container) PluginHost() (*pluginhost.Host, error) {
 			return
 		}
 
+		taskScheduler, schedulerErr := c.Scheduler()
+		if schedulerErr != nil {
+			err = schedulerErr
+
+			return
+		}
+
+		taskQueue, taskQueueErr := c.TaskQueue()
+		if taskQueueErr != nil {
+			err = taskQueueErr
+
+			return
+		}
+
+		mqttPublisher, mqttPublisherErr := c.MQTTPublisher()
+		if mqttPublisherErr != nil {
+			err = mqttPublisherErr
+
+			return
+		}
+
 		c.pluginHost.instance, err = pluginhost.New(
 			c.Logger(),
 			db,
 			notifier,
 			telegramBot,
 			telegramConversationEngine,
+			taskScheduler,
+			taskQueue,
+			mqttPublisher,
 		)
 	})
 
@@ -120,6 +144,11 @@ func (c *Container) buildPluginLoader() (*pluginloader.Loader, error) {
 		return nil, err
 	}
 
+	homeAssistantEntityRegistry, err := c.HomeAssistantEntityRegistry()
+	if err != nil {
+		return nil, err
+	}
+
 	migrationRegistry, err := c.MigrationRegistry()
 	if err != nil {
 		return nil, err
@@ -132,6 +161,16 @@ func (c *Container) buildPluginLoader() (*pluginloader.Loader, error) {
 
 	pluginRegistry := c.PluginRegistry()
 
+	scheduledTaskRegistry, err := c.ScheduledTaskRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	taskHandlerRegistry, err := c.TaskHandlerRegistry()
+	if err != nil {
+		return nil, err
+	}
+
 	telegramCommandRegistry, err := c.TelegramCommandRegistry()
 	if err != nil {
 		return nil, err
@@ -142,6 +181,11 @@ func (c *Container) buildPluginLoader() (*pluginloader.Loader, error) {
 		return nil, err
 	}
 
+	workerRegistry, err := c.WorkerRegistry()
+	if err != nil {
+		return nil, err
+	}
+
 	return pluginloader.New(
 		pluginHost,
 		cfg,
@@ -149,11 +193,15 @@ func (c *Container) buildPluginLoader() (*pluginloader.Loader, error) {
 		commandRegistry,
 		cronRegistry,
 		handlerRegistry,
+		homeAssistantEntityRegistry,
 		migrationRegistry,
 		mqttSubscriberRegistry,
 		pluginRegistry,
+		scheduledTaskRegistry,
+		taskHandlerRegistry,
 		telegramCommandRegistry,
 		telegramConversationRegistry,
 		c.UIRegistry(),
+		workerRegistry,
 	), nil
 }
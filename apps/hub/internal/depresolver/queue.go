@@ -0,0 +1,52 @@
+package depresolver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/abgeo/maroid/apps/hub/internal/queue"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+)
+
+// TaskHandlerRegistry initializes and returns the task handler registry instance.
+func (c *Container) TaskHandlerRegistry() (*registry.TaskHandlerRegistry, error) {
+	c.taskHandlerRegistry.once.Do(func() {
+		c.taskHandlerRegistry.instance = registry.NewTaskHandlerRegistry()
+	})
+
+	return c.taskHandlerRegistry.instance, nil
+}
+
+// TaskQueue initializes and returns the task queue instance.
+func (c *Container) TaskQueue() (*queue.Queue, error) {
+	c.taskQueue.mu.Lock()
+	defer c.taskQueue.mu.Unlock()
+
+	var err error
+
+	c.taskQueue.once.Do(func() {
+		db, dbErr := c.Database()
+		if dbErr != nil {
+			err = dbErr
+
+			return
+		}
+
+		taskHandlerRegistry, registryErr := c.TaskHandlerRegistry()
+		if registryErr != nil {
+			err = registryErr
+
+			return
+		}
+
+		c.taskQueue.instance = queue.New(c.Config(), c.Logger(), db, taskHandlerRegistry)
+	})
+
+	if err != nil {
+		c.taskQueue.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing task queue: %w", err)
+	}
+
+	return c.taskQueue.instance, nil
+}
@@ -21,3 +21,21 @@ func (c *Container) UIRegistry() *registry.UIRegistry {
 
 	return c.uiRegistry.instance
 }
+
+// WorkerRegistry initializes and returns the plugin worker registry instance.
+func (c *Container) WorkerRegistry() (*registry.WorkerRegistry, error) {
+	c.workerRegistry.once.Do(func() {
+		c.workerRegistry.instance = registry.NewWorkerRegistry()
+	})
+
+	return c.workerRegistry.instance, nil
+}
+
+// HomeAssistantEntityRegistry initializes and returns the Home Assistant entity registry instance.
+func (c *Container) HomeAssistantEntityRegistry() (*registry.HomeAssistantEntityRegistry, error) {
+	c.homeAssistantEntityRegistry.once.Do(func() {
+		c.homeAssistantEntityRegistry.instance = registry.NewHomeAssistantEntityRegistry()
+	})
+
+	return c.homeAssistantEntityRegistry.instance, nil
+}
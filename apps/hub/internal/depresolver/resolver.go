@@ -14,18 +14,30 @@ import (
 	"github.com/mymmrac/telego"
 	"github.com/robfig/cron/v3"
 
+	"github.com/abgeo/maroid/apps/hub/internal/alert"
 	"github.com/abgeo/maroid/apps/hub/internal/auth"
 	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/cronschedule"
+	"github.com/abgeo/maroid/apps/hub/internal/device"
 	"github.com/abgeo/maroid/apps/hub/internal/handler"
+	"github.com/abgeo/maroid/apps/hub/internal/homeassistant"
 	"github.com/abgeo/maroid/apps/hub/internal/logger"
 	"github.com/abgeo/maroid/apps/hub/internal/migrator"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttbroker"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttdlq"
+	"github.com/abgeo/maroid/apps/hub/internal/outbox"
 	pluginhost "github.com/abgeo/maroid/apps/hub/internal/plugin/host"
 	pluginloader "github.com/abgeo/maroid/apps/hub/internal/plugin/loader"
+	"github.com/abgeo/maroid/apps/hub/internal/queue"
 	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/apps/hub/internal/scheduler"
 	"github.com/abgeo/maroid/apps/hub/internal/telegram"
 	"github.com/abgeo/maroid/apps/hub/internal/telegram/conversation"
+	"github.com/abgeo/maroid/apps/hub/internal/worker"
 	"github.com/abgeo/maroid/libs/notifier/dispatcher"
 	notifierregistry "github.com/abgeo/maroid/libs/notifier/registry"
+	"github.com/abgeo/maroid/libs/notifierapi"
 )
 
 // Resolver defines an interface for resolving shared dependencies.
@@ -51,12 +63,28 @@ type Resolver interface {
 	TelegramCommandRegistry() (*registry.TelegramCommandRegistry, error)
 	TelegramConversationRegistry() (*registry.TelegramConversationRegistry, error)
 	MQTTSubscriberRegistry() (*registry.MQTTSubscriberRegistry, error)
+	MQTTDeadLetterStore() (*mqttdlq.Store, error)
+	MQTTPublisher() (*mqttclient.Publisher, error)
+	MQTTBroker() (*mqttbroker.Broker, error)
+	DeviceRegistry() (*device.Registry, error)
+	HomeAssistantBridge() (*homeassistant.Bridge, error)
+	ScheduledTaskRegistry() (*registry.ScheduledTaskRegistry, error)
+	TaskHandlerRegistry() (*registry.TaskHandlerRegistry, error)
+	WorkerRegistry() (*registry.WorkerRegistry, error)
+	HomeAssistantEntityRegistry() (*registry.HomeAssistantEntityRegistry, error)
 	PluginRegistry() *registry.PluginRegistry
 	HandlerRegistry() (*handler.Registry, error)
 	UIRegistry() *registry.UIRegistry
-	Cron() *cron.Cron
+	Cron() (*cron.Cron, error)
+	CronScheduleParser() (*cronschedule.Parser, error)
+	CronAlertPolicy() (*alert.CronPolicy, error)
+	Scheduler() (*scheduler.Scheduler, error)
+	TaskQueue() (*queue.Queue, error)
+	Workers() ([]worker.Worker, error)
 	NotifierRegistry() (*notifierregistry.SchemeRegistry, error)
 	NotifierDispatcher() (*dispatcher.ChannelDispatcher, error)
+	NotificationOutbox() (*outbox.Outbox, error)
+	Notifier() (notifierapi.Dispatcher, error)
 	TelegramBot() (*telego.Bot, error)
 	TelegramUpdatesHandler() (*telegram.ChannelHandler, error)
 	Close(ctx context.Context) error
@@ -69,10 +97,17 @@ type Container struct {
 	logger *slog.Logger
 
 	cron struct {
+		mu       sync.Mutex
 		once     sync.Once
 		instance *cron.Cron
 	}
 
+	cronScheduleParser struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance *cronschedule.Parser
+	}
+
 	database struct {
 		mu       sync.Mutex
 		once     sync.Once
@@ -132,6 +167,12 @@ type Container struct {
 		instance *registry.CommandRegistry
 	}
 
+	cronAlertPolicy struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance *alert.CronPolicy
+	}
+
 	cronRegistry struct {
 		once     sync.Once
 		instance *registry.CronRegistry
@@ -155,11 +196,79 @@ type Container struct {
 		instance *registry.TelegramConversationRegistry
 	}
 
+	mqttDeadLetterStore struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance *mqttdlq.Store
+	}
+
+	mqttPublisher struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance *mqttclient.Publisher
+	}
+
+	deviceRegistry struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance *device.Registry
+	}
+
+	homeAssistantBridge struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance *homeassistant.Bridge
+	}
+
+	mqttBroker struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance *mqttbroker.Broker
+	}
+
 	mqttSubscriberRegistry struct {
 		once     sync.Once
 		instance *registry.MQTTSubscriberRegistry
 	}
 
+	scheduledTaskRegistry struct {
+		once     sync.Once
+		instance *registry.ScheduledTaskRegistry
+	}
+
+	scheduler struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance *scheduler.Scheduler
+	}
+
+	taskHandlerRegistry struct {
+		once     sync.Once
+		instance *registry.TaskHandlerRegistry
+	}
+
+	taskQueue struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance *queue.Queue
+	}
+
+	workerRegistry struct {
+		once     sync.Once
+		instance *registry.WorkerRegistry
+	}
+
+	homeAssistantEntityRegistry struct {
+		once     sync.Once
+		instance *registry.HomeAssistantEntityRegistry
+	}
+
+	workers struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance []worker.Worker
+	}
+
 	pluginRegistry struct {
 		once     sync.Once
 		instance *registry.PluginRegistry
@@ -188,6 +297,12 @@ type Container struct {
 		instance *dispatcher.ChannelDispatcher
 	}
 
+	notificationOutbox struct {
+		mu       sync.Mutex
+		once     sync.Once
+		instance *outbox.Outbox
+	}
+
 	telegramBot struct {
 		mu       sync.Mutex
 		once     sync.Once
@@ -244,6 +359,7 @@ func (c *Container) Close(_ context.Context) error {
 
 	errList = append(errList,
 		c.CloseHTTPServer(),
+		c.CloseMQTTPublisher(),
 		c.CloseDatabase(),
 	)
 
@@ -0,0 +1,52 @@
+package depresolver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/apps/hub/internal/scheduler"
+)
+
+// ScheduledTaskRegistry initializes and returns the scheduled task handler registry instance.
+func (c *Container) ScheduledTaskRegistry() (*registry.ScheduledTaskRegistry, error) {
+	c.scheduledTaskRegistry.once.Do(func() {
+		c.scheduledTaskRegistry.instance = registry.NewScheduledTaskRegistry()
+	})
+
+	return c.scheduledTaskRegistry.instance, nil
+}
+
+// Scheduler initializes and returns the runtime task scheduler instance.
+func (c *Container) Scheduler() (*scheduler.Scheduler, error) {
+	c.scheduler.mu.Lock()
+	defer c.scheduler.mu.Unlock()
+
+	var err error
+
+	c.scheduler.once.Do(func() {
+		db, dbErr := c.Database()
+		if dbErr != nil {
+			err = dbErr
+
+			return
+		}
+
+		scheduledTaskRegistry, registryErr := c.ScheduledTaskRegistry()
+		if registryErr != nil {
+			err = registryErr
+
+			return
+		}
+
+		c.scheduler.instance = scheduler.New(c.Config(), c.Logger(), db, scheduledTaskRegistry)
+	})
+
+	if err != nil {
+		c.scheduler.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing scheduler: %w", err)
+	}
+
+	return c.scheduler.instance, nil
+}
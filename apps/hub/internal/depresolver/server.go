@@ -127,7 +127,30 @@ func (c *Container) registerHandlers(reg *handler.Registry) error {
 		return err
 	}
 
+	cronRegistry, err := c.CronRegistry()
+	if err != nil {
+		return err
+	}
+
+	cronScheduleParser, err := c.CronScheduleParser()
+	if err != nil {
+		return err
+	}
+
+	mqttDeadLetterStore, err := c.MQTTDeadLetterStore()
+	if err != nil {
+		return err
+	}
+
+	deviceRegistry, err := c.DeviceRegistry()
+	if err != nil {
+		return err
+	}
+
 	authHandler := handler.NewAuth(cfg, logger, jwtSvc, oidcFlow)
+	cronHandler := handler.NewCron(cfg, logger, jwtSvc, cronRegistry, cronScheduleParser)
+	deviceHandler := handler.NewDevice(cfg, logger, jwtSvc, deviceRegistry)
+	mqttHandler := handler.NewMQTT(cfg, logger, jwtSvc, mqttDeadLetterStore)
 	pluginHandler := handler.NewPlugin(cfg, logger, jwtSvc, pluginRegistry, uiRegistry)
 
 	err = reg.Register("auth", authHandler)
@@ -135,6 +158,21 @@ func (c *Container) registerHandlers(reg *handler.Registry) error {
 		return fmt.Errorf("register auth handler: %w", err)
 	}
 
+	err = reg.Register("cron", cronHandler)
+	if err != nil {
+		return fmt.Errorf("register cron handler: %w", err)
+	}
+
+	err = reg.Register("device", deviceHandler)
+	if err != nil {
+		return fmt.Errorf("register device handler: %w", err)
+	}
+
+	err = reg.Register("mqtt", mqttHandler)
+	if err != nil {
+		return fmt.Errorf("register mqtt handler: %w", err)
+	}
+
 	err = reg.Register("ping", handler.NewPing(logger))
 	if err != nil {
 		return fmt.Errorf("register ping handler: %w", err)
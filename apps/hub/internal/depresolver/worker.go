@@ -0,0 +1,138 @@
+package depresolver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/abgeo/maroid/apps/hub/internal/mqttbroker"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttdlq"
+	"github.com/abgeo/maroid/apps/hub/internal/worker"
+)
+
+// Workers initializes and returns all available background workers,
+// including the ones provided by plugins.
+func (c *Container) Workers() ([]worker.Worker, error) {
+	c.workers.mu.Lock()
+	defer c.workers.mu.Unlock()
+
+	var err error
+
+	c.workers.once.Do(func() {
+		c.workers.instance, err = c.buildWorkers()
+	})
+
+	if err != nil {
+		c.workers.once = sync.Once{}
+
+		return nil, fmt.Errorf("initializing workers: %w", err)
+	}
+
+	return c.workers.instance, nil
+}
+
+func (c *Container) buildWorkers() ([]worker.Worker, error) {
+	cfg := c.Config()
+	logger := c.Logger()
+
+	cronScheduler, err := c.Cron()
+	if err != nil {
+		return nil, err
+	}
+
+	cronScheduleParser, err := c.CronScheduleParser()
+	if err != nil {
+		return nil, err
+	}
+
+	cronRegistry, err := c.CronRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	cronAlertPolicy, err := c.CronAlertPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	mqttSubscriberRegistry, err := c.MQTTSubscriberRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	var mqttDeadLetterStore *mqttdlq.Store
+	if cfg.MQTT.DeadLetter.Enabled {
+		mqttDeadLetterStore, err = c.MQTTDeadLetterStore()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var mqttBroker *mqttbroker.Broker
+	if cfg.MQTT.Embedded.Enabled {
+		mqttBroker, err = c.MQTTBroker()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	taskScheduler, err := c.Scheduler()
+	if err != nil {
+		return nil, err
+	}
+
+	taskQueue, err := c.TaskQueue()
+	if err != nil {
+		return nil, err
+	}
+
+	workerRegistry, err := c.WorkerRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	workers := []worker.Worker{
+		worker.NewCronWorker(
+			logger,
+			cronScheduler,
+			cronScheduleParser,
+			cronRegistry,
+			cronAlertPolicy,
+		),
+		worker.NewMQTTWorker(
+			logger,
+			cfg,
+			mqttSubscriberRegistry,
+			mqttDeadLetterStore,
+			mqttBroker,
+		),
+		worker.NewSchedulerWorker(logger, cfg, taskScheduler),
+		worker.NewQueueWorker(logger, cfg, taskQueue),
+	}
+
+	if cfg.HomeAssistant.Enabled {
+		homeAssistantBridge, bridgeErr := c.HomeAssistantBridge()
+		if bridgeErr != nil {
+			return nil, bridgeErr
+		}
+
+		workers = append(
+			workers,
+			worker.NewHomeAssistantWorker(logger, cfg, homeAssistantBridge, mqttBroker),
+		)
+	}
+
+	if cfg.NotifierOutbox.Enabled {
+		notificationOutbox, outboxErr := c.NotificationOutbox()
+		if outboxErr != nil {
+			return nil, outboxErr
+		}
+
+		workers = append(workers, worker.NewOutboxWorker(logger, cfg, notificationOutbox))
+	}
+
+	for _, entry := range workerRegistry.All() {
+		workers = append(workers, worker.NewPluginWorker(entry))
+	}
+
+	return workers, nil
+}
@@ -0,0 +1,272 @@
+// Package device manages the devices that connect to the MQTT broker on behalf of plugins.
+// Every device authenticates with its name and a generated password and may only use
+// the topic filters it was granted inside the namespace of its plugin.
+package device
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/abgeo/maroid/apps/hub/internal/database"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttbroker"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+	"github.com/abgeo/maroid/apps/hub/internal/repository"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+const passwordBytes = 24
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Registry is the device registry.
+type Registry struct {
+	logger *slog.Logger
+	db     *sqlx.DB
+}
+
+var _ mqttbroker.Credentials = (*Registry)(nil)
+
+// New creates a new Registry.
+func New(logger *slog.Logger, db *sqlx.DB) *Registry {
+	return &Registry{
+		logger: logger.With(
+			slog.String("component", "device"),
+		),
+		db: db,
+	}
+}
+
+// Add registers a device of the plugin and returns it with its generated password.
+// Only the password hash is stored, so the password cannot be retrieved later.
+func (r *Registry) Add(
+	ctx context.Context,
+	name string,
+	pluginID string,
+	topics []string,
+) (*model.Device, string, error) {
+	if !namePattern.MatchString(name) {
+		return nil, "", fmt.Errorf("%w: %q", errs.ErrInvalidDeviceName, name)
+	}
+
+	if pluginapi.ParsePluginID(pluginID) == nil {
+		return nil, "", fmt.Errorf("%w: %q", errs.ErrInvalidPluginID, pluginID)
+	}
+
+	for _, topic := range topics {
+		if err := mqttclient.ValidateRelativeTopic(topic, true); err != nil {
+			return nil, "", fmt.Errorf("validating topic %q: %w", topic, err)
+		}
+	}
+
+	password, err := generatePassword()
+	if err != nil {
+		return nil, "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, "", fmt.Errorf("hashing device password: %w", err)
+	}
+
+	entity := &model.Device{
+		Name:         name,
+		PluginID:     pluginID,
+		PasswordHash: string(hash),
+		Topics:       topics,
+	}
+
+	err = database.WithTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		repo := repository.NewDevice(tx)
+
+		if _, err := repo.Insert(ctx, entity); err != nil {
+			return err
+		}
+
+		var err error
+
+		entity, err = repo.GetByName(ctx, name)
+
+		return err
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("adding device: %w", err)
+	}
+
+	r.logger.InfoContext(ctx,
+		"device added",
+		slog.String("device", name),
+		slog.String("plugin_id", pluginID),
+	)
+
+	return entity, password, nil
+}
+
+// List returns all devices ordered by name, optionally only the ones of the given plugin.
+func (r *Registry) List(ctx context.Context, pluginID string) ([]model.Device, error) {
+	var entities []model.Device
+
+	err := database.WithTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		entities, err = repository.NewDevice(tx).List(ctx, pluginID)
+
+		return err
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return entities, nil
+}
+
+// Revoke revokes the device so that it can no longer authenticate.
+// The embedded broker disconnects the device once it refreshes the grants of its clients.
+func (r *Registry) Revoke(ctx context.Context, name string) error {
+	err := database.WithTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		return repository.NewDevice(tx).Revoke(ctx, name)
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	r.logger.InfoContext(ctx, "device revoked", slog.String("device", name))
+
+	return nil
+}
+
+// Authenticate checks the password of an active device, records that the device was seen
+// and returns the absolute topic filters the device may use.
+// It returns ErrInvalidMQTTCredentials for unknown or revoked devices and wrong passwords;
+// for unknown devices the error also wraps ErrDeviceNotFound.
+func (r *Registry) Authenticate(
+	ctx context.Context,
+	username string,
+	password []byte,
+) ([]string, error) {
+	var filters []string
+
+	err := database.WithTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		repo := repository.NewDevice(tx)
+
+		entity, err := repo.GetByName(ctx, username)
+		if errors.Is(err, errs.ErrDeviceNotFound) {
+			return fmt.Errorf("%w: %w", errs.ErrInvalidMQTTCredentials, err)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if entity.RevokedAt != nil {
+			return errs.ErrInvalidMQTTCredentials
+		}
+
+		err = bcrypt.CompareHashAndPassword([]byte(entity.PasswordHash), password)
+		if err != nil {
+			return errs.ErrInvalidMQTTCredentials
+		}
+
+		filters = TopicFilters(entity)
+
+		return repo.Touch(ctx, entity.ID)
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return filters, nil
+}
+
+// Grants returns the absolute topic filters of an active device, so that the embedded broker
+// can refresh the grants of a connected device. It returns ErrInvalidMQTTCredentials for unknown
+// and revoked devices; for unknown devices the error also wraps ErrDeviceNotFound.
+func (r *Registry) Grants(ctx context.Context, name string) ([]string, error) {
+	var entity *model.Device
+
+	err := database.WithTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		entity, err = repository.NewDevice(tx).GetByName(ctx, name)
+
+		return err
+	})
+	if errors.Is(err, errs.ErrDeviceNotFound) {
+		return nil, fmt.Errorf("%w: %w", errs.ErrInvalidMQTTCredentials, err)
+	}
+
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if entity.RevokedAt != nil {
+		return nil, errs.ErrInvalidMQTTCredentials
+	}
+
+	return TopicFilters(entity), nil
+}
+
+// Authorize reports whether the device may publish or subscribe to the topic or topic filter.
+// Revoked devices are never authorized. It returns ErrDeviceNotFound for unknown devices.
+func (r *Registry) Authorize(ctx context.Context, name string, topic string) (bool, error) {
+	var entity *model.Device
+
+	err := database.WithTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		entity, err = repository.NewDevice(tx).GetByName(ctx, name)
+
+		return err
+	})
+	if err != nil {
+		return false, err //nolint:wrapcheck
+	}
+
+	if entity.RevokedAt != nil {
+		return false, nil
+	}
+
+	for _, filter := range TopicFilters(entity) {
+		if mqttclient.FilterCovers(filter, topic) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// TopicFilters returns the topic filters of the device resolved against its plugin namespace.
+func TopicFilters(entity *model.Device) []string {
+	pluginID := pluginapi.ParsePluginID(entity.PluginID)
+	if pluginID == nil {
+		return nil
+	}
+
+	namespace := mqttclient.Namespace(pluginID)
+
+	filters := make([]string, 0, len(entity.Topics))
+	for _, topic := range entity.Topics {
+		filters = append(filters, namespace+"/"+topic)
+	}
+
+	return filters
+}
+
+func generatePassword() (string, error) {
+	buf := make([]byte, passwordBytes)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating device password: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -39,6 +39,82 @@ var (
 	ErrInvalidMQTTTopic = errors.New("mqtt subscriber: invalid topic")
 	// ErrMQTTBrokerNotConfigured indicates that MQTT subscribers are registered but no broker is configured.
 	ErrMQTTBrokerNotConfigured = errors.New("mqtt: broker not configured")
+	// ErrMQTTBrokerNotRunning indicates that the embedded MQTT broker has not been started.
+	ErrMQTTBrokerNotRunning = errors.New("mqtt: embedded broker not running")
+	// ErrInvalidMQTTCredentials indicates that an MQTT client presented a wrong username or password.
+	ErrInvalidMQTTCredentials = errors.New("mqtt: invalid credentials")
+	// ErrDeviceNotFound indicates that a device does not exist.
+	ErrDeviceNotFound = errors.New("device: not found")
+	// ErrDeviceAlreadyExists indicates that a device with the same name already exists.
+	ErrDeviceAlreadyExists = errors.New("device: already exists")
+	// ErrInvalidDeviceName indicates that a device name cannot be used as an MQTT username.
+	ErrInvalidDeviceName = errors.New("device: invalid name")
+	// ErrInvalidHomeAssistantEntity indicates that a Home Assistant entity declaration is invalid.
+	ErrInvalidHomeAssistantEntity = errors.New("home assistant entity: invalid")
+	// ErrHomeAssistantEntityAlreadyRegistered indicates that a plugin declares the same entity twice.
+	ErrHomeAssistantEntityAlreadyRegistered = errors.New("home assistant entity: already registered")
+	// ErrMQTTNotConnected indicates that an MQTT operation was attempted before connecting.
+	ErrMQTTNotConnected = errors.New("mqtt: not connected")
+	// ErrMQTTSubscriptionRejected indicates that the broker rejected a subscription.
+	ErrMQTTSubscriptionRejected = errors.New("mqtt: subscription rejected")
+	// ErrMQTTServerDisconnect indicates that the broker closed the connection.
+	ErrMQTTServerDisconnect = errors.New("mqtt: disconnected by server")
+	// ErrInvalidMQTTTLSConfig indicates that the MQTT TLS options cannot be loaded.
+	ErrInvalidMQTTTLSConfig = errors.New("mqtt: invalid TLS configuration")
+	// ErrMQTTSubscriberNotFound indicates that no MQTT subscriber is registered for a topic.
+	ErrMQTTSubscriberNotFound = errors.New("mqtt subscriber: not found")
+	// ErrMQTTDeadLetterNotFound indicates that a dead-lettered MQTT message does not exist.
+	ErrMQTTDeadLetterNotFound = errors.New("mqtt dead letter: not found")
+	// ErrMQTTDeadLetterReplayFailed indicates that the subscriber failed to handle a replayed message again.
+	ErrMQTTDeadLetterReplayFailed = errors.New("mqtt dead letter: replay failed")
+	// ErrMQTTSubscriberPanicked indicates that an MQTT subscriber panicked while handling a message.
+	ErrMQTTSubscriberPanicked = errors.New("mqtt subscriber: panicked")
+	// ErrUnsupportedMQTTCodec indicates that an MQTT subscriber declares an unknown payload codec.
+	ErrUnsupportedMQTTCodec = errors.New("mqtt subscriber: unsupported codec")
+	// ErrInvalidMQTTCodecValue indicates that a typed subscriber value cannot be decoded by its codec.
+	ErrInvalidMQTTCodecValue = errors.New("mqtt subscriber: invalid codec value")
+	// ErrInvalidMQTTPayload indicates that an MQTT message does not fit the subscriber declaration.
+	ErrInvalidMQTTPayload = errors.New("mqtt subscriber: invalid payload")
+	// ErrMQTTMessageDecode indicates that an MQTT message could not be decoded for a typed subscriber.
+	ErrMQTTMessageDecode = errors.New("mqtt subscriber: message decode failed")
+	// ErrInvalidCommandArguments indicates that a CLI command was invoked with conflicting or missing arguments.
+	ErrInvalidCommandArguments = errors.New("command: invalid arguments")
+	// ErrPluginHostNotBound indicates that a plugin-scoped capability was used before the plugin was constructed.
+	ErrPluginHostNotBound = errors.New("plugin host: not bound to a plugin")
+	// ErrCronJobNotFound indicates that a requested cron job is not registered.
+	ErrCronJobNotFound = errors.New("cron: job not found")
+	// ErrCronJobNotBackfillable indicates that a cron job does not support backfilling.
+	ErrCronJobNotBackfillable = errors.New("cron: job does not support backfill")
+	// ErrInvalidBackfillRange indicates that a backfill range is malformed.
+	ErrInvalidBackfillRange = errors.New("cron: invalid backfill range")
+	// ErrAlertChannelNotFound indicates that the configured alert channel does not exist in the notifier config.
+	ErrAlertChannelNotFound = errors.New("alert: notifier channel not found")
 	// ErrUnknownWorkerType indicates that a requested worker type is not registered.
 	ErrUnknownWorkerType = errors.New("worker: unknown type")
+	// ErrWorkerAlreadyRegistered indicates that a plugin worker has already been registered.
+	ErrWorkerAlreadyRegistered = errors.New("worker: already registered")
+	// ErrInvalidWorkerName indicates that a plugin worker name is empty or contains a colon.
+	ErrInvalidWorkerName = errors.New("worker: invalid name")
+	// ErrWorkerFailed indicates that a worker exhausted its restart budget.
+	ErrWorkerFailed = errors.New("worker: failed permanently")
+	// ErrWorkerPanicked indicates that a worker panicked while running.
+	ErrWorkerPanicked = errors.New("worker: panicked")
+	// ErrScheduledTaskHandlerAlreadyRegistered indicates that a scheduled task handler has already been registered.
+	ErrScheduledTaskHandlerAlreadyRegistered = errors.New("scheduled task handler: already registered")
+	// ErrScheduledTaskHandlerNotFound indicates that a task was scheduled for an unknown handler.
+	ErrScheduledTaskHandlerNotFound = errors.New("scheduled task handler: not found")
+	// ErrInvalidScheduledTask indicates that a scheduled task definition is invalid.
+	ErrInvalidScheduledTask = errors.New("scheduled task: invalid")
+	// ErrTaskHandlerAlreadyRegistered indicates that a handler has already been registered for a task type.
+	ErrTaskHandlerAlreadyRegistered = errors.New("task handler: already registered")
+	// ErrTaskHandlerNotFound indicates that no handler is registered for a task type.
+	ErrTaskHandlerNotFound = errors.New("task handler: not found")
+	// ErrTaskAttemptsExhausted indicates that a queued task has no attempts left.
+	ErrTaskAttemptsExhausted = errors.New("task: attempts exhausted")
+	// ErrNotificationNotFound indicates that an outbox notification does not exist.
+	ErrNotificationNotFound = errors.New("notification: not found")
+	// ErrNotificationAttemptsExhausted indicates that an outbox notification delivery has no attempts left.
+	ErrNotificationAttemptsExhausted = errors.New("notification: attempts exhausted")
+	// ErrNotificationNotResendable indicates that an outbox notification has no failed deliveries to re-send.
+	ErrNotificationNotResendable = errors.New("notification: no failed deliveries")
 )
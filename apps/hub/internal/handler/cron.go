@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/abgeo/maroid/apps/hub/internal/auth"
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/cronrun"
+	"github.com/abgeo/maroid/apps/hub/internal/cronschedule"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+)
+
+const (
+	defaultCronPreviewRuns = 3
+	maxCronPreviewRuns     = 20
+)
+
+// CronHandler represents the Cron handler interface.
+type CronHandler interface {
+	Handler
+
+	ListJobs(w http.ResponseWriter, r *http.Request) error
+	RunJob(w http.ResponseWriter, r *http.Request) error
+}
+
+// Cron represents the cron handler.
+type Cron struct {
+	cfg          *config.Config
+	logger       *slog.Logger
+	jwtSvc       *auth.JWTService
+	cronRegistry *registry.CronRegistry
+	parser       *cronschedule.Parser
+	runner       *cronrun.Runner
+}
+
+var _ CronHandler = (*Cron)(nil)
+
+// NewCron creates a new Cron handler.
+func NewCron(
+	cfg *config.Config,
+	logger *slog.Logger,
+	jwtSvc *auth.JWTService,
+	cronRegistry *registry.CronRegistry,
+	parser *cronschedule.Parser,
+) *Cron {
+	return &Cron{
+		cfg: cfg,
+		logger: logger.With(
+			slog.String("component", "handler"),
+			slog.String("handler", "cron"),
+		),
+		jwtSvc:       jwtSvc,
+		cronRegistry: cronRegistry,
+		parser:       parser,
+		runner:       cronrun.NewRunner(logger, cronRegistry),
+	}
+}
+
+// @todo: move to dedicated package.
+type cronRunEntry struct {
+	ID            string                  `json:"id"`
+	DryRun        bool                    `json:"dry_run"`
+	StartedAt     time.Time               `json:"started_at"`
+	DurationMS    int64                   `json:"duration_ms"`
+	Error         string                  `json:"error,omitempty"`
+	Notifications []cronNotificationEntry `json:"notifications,omitempty"`
+}
+
+type cronNotificationEntry struct {
+	Channel     string   `json:"channel"`
+	Title       string   `json:"title"`
+	Body        string   `json:"body"`
+	Attachments []string `json:"attachments,omitempty"`
+}
+
+// Register registers the cron routes.
+func (h *Cron) Register(router chi.Router) {
+	h.logger.Debug("registering routes")
+
+	router.Route("/cron", func(r chi.Router) {
+		r.Use(auth.Middleware(h.logger, h.jwtSvc, h.cfg.Telegram.AllowedUsers))
+
+		r.Get("/jobs", Wrap(h.logger, h.ListJobs))
+		r.Post("/jobs/{id}/run", Wrap(h.logger, h.RunJob))
+	})
+}
+
+// ListJobs returns all registered cron jobs with their schedules and upcoming runs.
+// The number of previewed runs is controlled by the "next" query parameter.
+func (h *Cron) ListJobs(w http.ResponseWriter, r *http.Request) error {
+	next := defaultCronPreviewRuns
+
+	if raw := r.URL.Query().Get("next"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 || value > maxCronPreviewRuns {
+			http.Error(w, "Invalid 'next' parameter", http.StatusBadRequest)
+
+			return fmt.Errorf(
+				"%w: next must be between 0 and %d",
+				errInvalidQueryParameter,
+				maxCronPreviewRuns,
+			)
+		}
+
+		next = value
+	}
+
+	previews := h.parser.Previews(h.cronRegistry.All(), time.Now(), next)
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, previews)
+
+	return nil
+}
+
+// RunJob runs a cron job once and waits for it to finish.
+// Setting the "dry_run" query parameter to true runs the job in dry-run mode
+// and returns the notifications recorded instead of being sent.
+func (h *Cron) RunJob(w http.ResponseWriter, r *http.Request) error {
+	id := chi.URLParam(r, "id")
+
+	dryRun := false
+
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			http.Error(w, "Invalid 'dry_run' parameter", http.StatusBadRequest)
+
+			return fmt.Errorf("%w: dry_run must be a boolean", errInvalidQueryParameter)
+		}
+
+		dryRun = value
+	}
+
+	result, err := h.runner.Run(r.Context(), id, dryRun)
+	if errors.Is(err, errs.ErrCronJobNotFound) {
+		http.NotFound(w, r)
+
+		return nil
+	}
+
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return fmt.Errorf("running cron job: %w", err)
+	}
+
+	entry := cronRunEntry{
+		ID:         result.JobID,
+		DryRun:     result.DryRun,
+		StartedAt:  result.StartedAt,
+		DurationMS: result.Duration.Milliseconds(),
+	}
+
+	if result.Err != nil {
+		entry.Error = result.Err.Error()
+	}
+
+	for _, notification := range result.Notifications {
+		attachments := make([]string, 0, len(notification.Message.Attachments))
+		for _, attachment := range notification.Message.Attachments {
+			attachments = append(attachments, attachment.Filename)
+		}
+
+		entry.Notifications = append(entry.Notifications, cronNotificationEntry{
+			Channel:     notification.Channel,
+			Title:       notification.Message.Title,
+			Body:        notification.Message.Text(),
+			Attachments: attachments,
+		})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, entry)
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/abgeo/maroid/apps/hub/internal/auth"
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/device"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+)
+
+// EMQX hook results.
+const (
+	emqxResultAllow  = "allow"
+	emqxResultDeny   = "deny"
+	emqxResultIgnore = "ignore"
+)
+
+// DeviceHandler represents the device handler interface.
+type DeviceHandler interface {
+	Handler
+
+	List(w http.ResponseWriter, r *http.Request) error
+	EMQXAuthenticate(w http.ResponseWriter, r *http.Request) error
+	EMQXAuthorize(w http.ResponseWriter, r *http.Request) error
+}
+
+// Device represents the device handler.
+type Device struct {
+	cfg      *config.Config
+	logger   *slog.Logger
+	jwtSvc   *auth.JWTService
+	registry *device.Registry
+}
+
+var _ DeviceHandler = (*Device)(nil)
+
+// NewDevice creates a new Device handler.
+func NewDevice(
+	cfg *config.Config,
+	logger *slog.Logger,
+	jwtSvc *auth.JWTService,
+	registry *device.Registry,
+) *Device {
+	return &Device{
+		cfg: cfg,
+		logger: logger.With(
+			slog.String("component", "handler"),
+			slog.String("handler", "device"),
+		),
+		jwtSvc:   jwtSvc,
+		registry: registry,
+	}
+}
+
+// @todo: move to dedicated package.
+type deviceEntry struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	PluginID   string     `json:"plugin_id"`
+	Topics     []string   `json:"topics"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// emqxRequest is the body EMQX is configured to send to the hooks, e.g.
+// {"username": "${username}", "password": "${password}", "topic": "${topic}"}.
+type emqxRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Topic    string `json:"topic"`
+}
+
+type emqxResponse struct {
+	Result      string `json:"result"`
+	IsSuperuser bool   `json:"is_superuser"`
+}
+
+// Register registers the device routes.
+// The EMQX hooks are only registered when a hook token is configured.
+func (h *Device) Register(router chi.Router) {
+	h.logger.Debug("registering routes")
+
+	router.Route("/devices", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(auth.Middleware(h.logger, h.jwtSvc, h.cfg.Telegram.AllowedUsers))
+			r.Get("/", Wrap(h.logger, h.List))
+		})
+
+		if h.cfg.MQTT.EMQX.HookToken == "" {
+			return
+		}
+
+		r.Group(func(r chi.Router) {
+			r.Use(h.hookTokenMiddleware)
+			r.Post("/emqx/authn", Wrap(h.logger, h.EMQXAuthenticate))
+			r.Post("/emqx/authz", Wrap(h.logger, h.EMQXAuthorize))
+		})
+	})
+}
+
+// List returns all registered devices ordered by name.
+// The "plugin" query parameter filters by plugin ID.
+func (h *Device) List(w http.ResponseWriter, r *http.Request) error {
+	entities, err := h.registry.List(r.Context(), r.URL.Query().Get("plugin"))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return fmt.Errorf("listing devices: %w", err)
+	}
+
+	entries := make([]deviceEntry, 0, len(entities))
+	for _, entity := range entities {
+		entries = append(entries, deviceEntry{
+			ID:         entity.ID,
+			Name:       entity.Name,
+			PluginID:   entity.PluginID,
+			Topics:     entity.Topics,
+			LastSeenAt: entity.LastSeenAt,
+			RevokedAt:  entity.RevokedAt,
+			CreatedAt:  entity.CreatedAt,
+		})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, entries)
+
+	return nil
+}
+
+// EMQXAuthenticate implements the EMQX HTTP authentication hook.
+// Unknown usernames are ignored, so that EMQX can fall through to its other authenticators.
+func (h *Device) EMQXAuthenticate(w http.ResponseWriter, r *http.Request) error {
+	req, err := decodeEMQXRequest(w, r)
+	if err != nil {
+		return err
+	}
+
+	result := emqxResultAllow
+
+	_, err = h.registry.Authenticate(r.Context(), req.Username, []byte(req.Password))
+
+	switch {
+	case errors.Is(err, errs.ErrDeviceNotFound):
+		result = emqxResultIgnore
+	case errors.Is(err, errs.ErrInvalidMQTTCredentials):
+		result = emqxResultDeny
+	case err != nil:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return fmt.Errorf("authenticating device: %w", err)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, emqxResponse{Result: result})
+
+	return nil
+}
+
+// EMQXAuthorize implements the EMQX HTTP authorization hook.
+// Unknown usernames are ignored; devices may only use the topic filters they were granted.
+func (h *Device) EMQXAuthorize(w http.ResponseWriter, r *http.Request) error {
+	req, err := decodeEMQXRequest(w, r)
+	if err != nil {
+		return err
+	}
+
+	result := emqxResultDeny
+
+	allowed, err := h.registry.Authorize(r.Context(), req.Username, req.Topic)
+
+	switch {
+	case errors.Is(err, errs.ErrDeviceNotFound):
+		result = emqxResultIgnore
+	case err != nil:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return fmt.Errorf("authorizing device: %w", err)
+	case allowed:
+		result = emqxResultAllow
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, emqxResponse{Result: result})
+
+	return nil
+}
+
+func (h *Device) hookTokenMiddleware(next http.Handler) http.Handler {
+	expected := []byte(h.cfg.MQTT.EMQX.HookToken)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		if subtle.ConstantTimeCompare([]byte(token), expected) != 1 {
+			h.logger.WarnContext(r.Context(), "rejected EMQX hook request with invalid token")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func decodeEMQXRequest(w http.ResponseWriter, r *http.Request) (*emqxRequest, error) {
+	var req emqxRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+
+		return nil, fmt.Errorf("decoding EMQX hook request: %w", err)
+	}
+
+	return &req, nil
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/abgeo/maroid/apps/hub/internal/auth"
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttdlq"
+)
+
+const (
+	defaultMQTTDeadLetterLimit = 100
+	maxMQTTDeadLetterLimit     = 1000
+)
+
+// MQTTHandler represents the MQTT handler interface.
+type MQTTHandler interface {
+	Handler
+
+	ListDeadLetters(w http.ResponseWriter, r *http.Request) error
+	ReplayDeadLetter(w http.ResponseWriter, r *http.Request) error
+	DeleteDeadLetter(w http.ResponseWriter, r *http.Request) error
+}
+
+// MQTT represents the MQTT handler.
+type MQTT struct {
+	cfg         *config.Config
+	logger      *slog.Logger
+	jwtSvc      *auth.JWTService
+	deadLetters *mqttdlq.Store
+}
+
+var _ MQTTHandler = (*MQTT)(nil)
+
+// NewMQTT creates a new MQTT handler.
+func NewMQTT(
+	cfg *config.Config,
+	logger *slog.Logger,
+	jwtSvc *auth.JWTService,
+	deadLetters *mqttdlq.Store,
+) *MQTT {
+	return &MQTT{
+		cfg: cfg,
+		logger: logger.With(
+			slog.String("component", "handler"),
+			slog.String("handler", "mqtt"),
+		),
+		jwtSvc:      jwtSvc,
+		deadLetters: deadLetters,
+	}
+}
+
+// @todo: move to dedicated package.
+type mqttDeadLetterEntry struct {
+	ID            string    `json:"id"`
+	SubscriberID  string    `json:"subscriber_id"`
+	Subscription  string    `json:"subscription"`
+	Topic         string    `json:"topic"`
+	RelativeTopic string    `json:"relative_topic"`
+	Payload       []byte    `json:"payload"`
+	Error         string    `json:"error"`
+	Attempts      int       `json:"attempts"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type mqttReplayEntry struct {
+	ID       string `json:"id"`
+	Replayed bool   `json:"replayed"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Register registers the MQTT routes.
+func (h *MQTT) Register(router chi.Router) {
+	h.logger.Debug("registering routes")
+
+	router.Route("/mqtt", func(r chi.Router) {
+		r.Use(auth.Middleware(h.logger, h.jwtSvc, h.cfg.Telegram.AllowedUsers))
+
+		r.Get("/dead-letters", Wrap(h.logger, h.ListDeadLetters))
+		r.Post("/dead-letters/{id}/replay", Wrap(h.logger, h.ReplayDeadLetter))
+		r.Delete("/dead-letters/{id}", Wrap(h.logger, h.DeleteDeadLetter))
+	})
+}
+
+// ListDeadLetters returns dead-lettered MQTT messages, oldest first.
+// The "subscriber" query parameter filters by subscriber ID and "limit" bounds the result size.
+func (h *MQTT) ListDeadLetters(w http.ResponseWriter, r *http.Request) error {
+	limit := defaultMQTTDeadLetterLimit
+
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 || value > maxMQTTDeadLetterLimit {
+			http.Error(w, "Invalid 'limit' parameter", http.StatusBadRequest)
+
+			return fmt.Errorf(
+				"%w: limit must be between 1 and %d",
+				errInvalidQueryParameter,
+				maxMQTTDeadLetterLimit,
+			)
+		}
+
+		limit = value
+	}
+
+	entities, err := h.deadLetters.List(r.Context(), r.URL.Query().Get("subscriber"), limit)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return fmt.Errorf("listing MQTT dead letters: %w", err)
+	}
+
+	entries := make([]mqttDeadLetterEntry, 0, len(entities))
+	for _, entity := range entities {
+		entries = append(entries, mqttDeadLetterEntry{
+			ID:            entity.ID,
+			SubscriberID:  entity.SubscriberID,
+			Subscription:  entity.Subscription,
+			Topic:         entity.Topic,
+			RelativeTopic: entity.RelativeTopic,
+			Payload:       entity.Payload,
+			Error:         entity.LastError,
+			Attempts:      entity.Attempts,
+			CreatedAt:     entity.CreatedAt,
+			UpdatedAt:     entity.UpdatedAt,
+		})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, entries)
+
+	return nil
+}
+
+// ReplayDeadLetter re-dispatches a dead-lettered MQTT message to its subscriber.
+// A message the subscriber fails to handle again is kept and reported with the error.
+func (h *MQTT) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) error {
+	id := chi.URLParam(r, "id")
+
+	err := h.deadLetters.Replay(r.Context(), id)
+	if errors.Is(err, errs.ErrMQTTDeadLetterNotFound) {
+		http.NotFound(w, r)
+
+		return nil
+	}
+
+	entry := mqttReplayEntry{ID: id, Replayed: err == nil}
+
+	if err != nil && !errors.Is(err, errs.ErrMQTTDeadLetterReplayFailed) {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return fmt.Errorf("replaying MQTT dead letter: %w", err)
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, entry)
+
+	return nil
+}
+
+// DeleteDeadLetter removes a dead-lettered MQTT message without replaying it.
+func (h *MQTT) DeleteDeadLetter(w http.ResponseWriter, r *http.Request) error {
+	err := h.deadLetters.Delete(r.Context(), chi.URLParam(r, "id"))
+	if errors.Is(err, errs.ErrMQTTDeadLetterNotFound) {
+		http.NotFound(w, r)
+
+		return nil
+	}
+
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return fmt.Errorf("deleting MQTT dead letter: %w", err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/abgeo/maroid/apps/hub/internal/worker"
+)
+
+const workerStatusServerReadHeaderTimeout = 5 * time.Second
+
+// WorkerStatusProvider provides the states of supervised workers.
+type WorkerStatusProvider interface {
+	Statuses() []worker.Status
+}
+
+// WorkerHandler represents the Worker handler interface.
+type WorkerHandler interface {
+	Handler
+
+	Status(w http.ResponseWriter, r *http.Request) error
+}
+
+// Worker represents the worker status handler.
+type Worker struct {
+	logger   *slog.Logger
+	provider WorkerStatusProvider
+}
+
+var _ WorkerHandler = (*Worker)(nil)
+
+// NewWorker creates a new Worker handler.
+func NewWorker(logger *slog.Logger, provider WorkerStatusProvider) *Worker {
+	return &Worker{
+		logger: logger.With(
+			slog.String("component", "handler"),
+			slog.String("handler", "worker"),
+		),
+		provider: provider,
+	}
+}
+
+// NewWorkerStatusServer creates an HTTP server that serves only the worker states on address.
+// The route is not authenticated, so it must not be registered on the public API router.
+func NewWorkerStatusServer(
+	logger *slog.Logger,
+	address string,
+	provider WorkerStatusProvider,
+) *http.Server {
+	router := chi.NewRouter()
+	router.Use(render.SetContentType(render.ContentTypeJSON))
+
+	RegisterHandlers(router, NewWorker(logger, provider))
+
+	return &http.Server{
+		Addr:              address,
+		Handler:           router,
+		ReadHeaderTimeout: workerStatusServerReadHeaderTimeout,
+	}
+}
+
+// Register registers the worker routes.
+func (h *Worker) Register(router chi.Router) {
+	h.logger.Debug("registering routes")
+
+	router.Get("/workers/status", Wrap(h.logger, h.Status))
+}
+
+// Status returns the state of every supervised worker.
+// It responds with 503 Service Unavailable if any worker has failed permanently.
+func (h *Worker) Status(w http.ResponseWriter, r *http.Request) error {
+	statuses := h.provider.Statuses()
+
+	status := http.StatusOK
+
+	for _, workerStatus := range statuses {
+		if workerStatus.State == worker.StateFailed {
+			status = http.StatusServiceUnavailable
+
+			break
+		}
+	}
+
+	render.Status(r, status)
+	render.JSON(w, r, statuses)
+
+	return nil
+}
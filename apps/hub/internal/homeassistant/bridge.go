@@ -0,0 +1,319 @@
+// Package homeassistant exposes plugin entities to Home Assistant through MQTT discovery.
+package homeassistant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+const (
+	availabilityOnline  = "online"
+	availabilityOffline = "offline"
+	payloadPress        = "PRESS"
+	manufacturer        = "maroid"
+	qos                 = 1
+)
+
+// Bridge publishes retained discovery configs and states of the registered entities and
+// routes button presses back to their plugins.
+// Discovery configs are published under <discovery prefix>/<component>/<node id>/<object id>/config;
+// state and command topics live in the plugin namespace under homeassistant/<entity id>.
+type Bridge struct {
+	cfg      config.HomeAssistant
+	logger   *slog.Logger
+	registry *registry.HomeAssistantEntityRegistry
+
+	once     sync.Once
+	entities []*entity
+}
+
+type entity struct {
+	registry.HomeAssistantEntityEntry
+
+	meta           pluginapi.HomeAssistantEntityMeta
+	uniqueID       string
+	discoveryTopic string
+	stateTopic     string
+	commandTopic   string
+}
+
+type discoveryConfig struct {
+	Name              string          `json:"name"`
+	UniqueID          string          `json:"unique_id"`
+	StateTopic        string          `json:"state_topic,omitempty"`
+	CommandTopic      string          `json:"command_topic,omitempty"`
+	PayloadPress      string          `json:"payload_press,omitempty"`
+	DeviceClass       string          `json:"device_class,omitempty"`
+	StateClass        string          `json:"state_class,omitempty"`
+	UnitOfMeasurement string          `json:"unit_of_measurement,omitempty"`
+	Icon              string          `json:"icon,omitempty"`
+	AvailabilityTopic string          `json:"availability_topic"`
+	Device            discoveryDevice `json:"device"`
+}
+
+type discoveryDevice struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+	SWVersion    string   `json:"sw_version,omitempty"`
+}
+
+// New creates a new Bridge.
+func New(
+	cfg *config.Config,
+	logger *slog.Logger,
+	entityRegistry *registry.HomeAssistantEntityRegistry,
+) *Bridge {
+	return &Bridge{
+		cfg:      cfg.HomeAssistant,
+		logger:   logger.With(slog.String("component", "homeassistant")),
+		registry: entityRegistry,
+	}
+}
+
+// Empty reports whether no entities are registered.
+func (b *Bridge) Empty() bool {
+	return len(b.load()) == 0
+}
+
+// AvailabilityTopic returns the topic the availability of the hub is published on.
+func (b *Bridge) AvailabilityTopic() string {
+	return b.cfg.NodeID + "/homeassistant/availability"
+}
+
+// Will returns the last will that marks all entities unavailable
+// when the connection is lost without a clean disconnect.
+func (b *Bridge) Will() *mqttclient.Will {
+	return &mqttclient.Will{
+		Topic:    b.AvailabilityTopic(),
+		Payload:  []byte(availabilityOffline),
+		QoS:      qos,
+		Retained: true,
+	}
+}
+
+// Connected subscribes to the Home Assistant status topic and the button command topics,
+// then announces all entities. It must be called after every (re)connect.
+func (b *Bridge) Connected(ctx context.Context, conn mqttclient.Conn) error {
+	err := conn.Subscribe(ctx, mqttclient.Subscription{
+		Topic: b.cfg.StatusTopic,
+		QoS:   qos,
+		Handler: func(msg mqttclient.Message) {
+			if string(msg.Payload) != availabilityOnline {
+				return
+			}
+
+			// Home Assistant (re)started: it has to be told about the entities again.
+			go b.announce(ctx, conn)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("subscribing to Home Assistant status: %w", err)
+	}
+
+	for _, ent := range b.load() {
+		if ent.meta.Component != pluginapi.HomeAssistantButton {
+			continue
+		}
+
+		err = conn.Subscribe(ctx, mqttclient.Subscription{
+			Topic: ent.commandTopic,
+			QoS:   qos,
+			Handler: func(msg mqttclient.Message) {
+				if string(msg.Payload) != payloadPress {
+					return
+				}
+
+				go b.press(ctx, ent)
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("subscribing to commands of %s: %w", ent.Key, err)
+		}
+	}
+
+	b.announce(ctx, conn)
+
+	return nil
+}
+
+// Poll publishes the state of every sensor and binary sensor at its interval
+// and blocks until ctx is done.
+func (b *Bridge) Poll(ctx context.Context, conn mqttclient.Conn) {
+	var wg sync.WaitGroup
+
+	for _, ent := range b.load() {
+		if _, ok := ent.Entity.(pluginapi.HomeAssistantStateEntity); !ok {
+			continue
+		}
+
+		interval := ent.meta.StateInterval
+		if interval <= 0 {
+			interval = b.cfg.StateInterval
+		}
+
+		wg.Go(func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					b.publishState(ctx, conn, ent)
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+}
+
+// Offline marks all entities unavailable.
+func (b *Bridge) Offline(ctx context.Context, conn mqttclient.Conn) error {
+	err := conn.Publish(ctx, b.AvailabilityTopic(), []byte(availabilityOffline), qos, true)
+	if err != nil {
+		return fmt.Errorf("publishing Home Assistant availability: %w", err)
+	}
+
+	return nil
+}
+
+// announce publishes the discovery configs, the availability and the current states.
+func (b *Bridge) announce(ctx context.Context, conn mqttclient.Conn) {
+	for _, ent := range b.load() {
+		if err := b.publishDiscovery(ctx, conn, ent); err != nil {
+			b.logger.ErrorContext(ctx,
+				"failed to publish Home Assistant discovery config",
+				slog.String("entity", ent.Key),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	err := conn.Publish(ctx, b.AvailabilityTopic(), []byte(availabilityOnline), qos, true)
+	if err != nil {
+		b.logger.ErrorContext(ctx,
+			"failed to publish Home Assistant availability",
+			slog.Any("error", err),
+		)
+	}
+
+	for _, ent := range b.load() {
+		if _, ok := ent.Entity.(pluginapi.HomeAssistantStateEntity); ok {
+			b.publishState(ctx, conn, ent)
+		}
+	}
+
+	b.logger.InfoContext(ctx, "Home Assistant entities announced", slog.Int("entities", len(b.load())))
+}
+
+func (b *Bridge) publishDiscovery(ctx context.Context, conn mqttclient.Conn, ent *entity) error {
+	discovery := discoveryConfig{
+		Name:              ent.meta.Name,
+		UniqueID:          ent.uniqueID,
+		DeviceClass:       ent.meta.DeviceClass,
+		StateClass:        ent.meta.StateClass,
+		UnitOfMeasurement: ent.meta.UnitOfMeasurement,
+		Icon:              ent.meta.Icon,
+		AvailabilityTopic: b.AvailabilityTopic(),
+		Device: discoveryDevice{
+			Identifiers:  []string{b.cfg.NodeID + "_" + ent.Plugin.ID.ToSafeName("_")},
+			Name:         ent.Plugin.ID.String(),
+			Manufacturer: manufacturer,
+			SWVersion:    ent.Plugin.Version,
+		},
+	}
+
+	if ent.meta.Component == pluginapi.HomeAssistantButton {
+		discovery.CommandTopic = ent.commandTopic
+		discovery.PayloadPress = payloadPress
+	} else {
+		discovery.StateTopic = ent.stateTopic
+	}
+
+	payload, err := json.Marshal(discovery)
+	if err != nil {
+		return fmt.Errorf("encoding discovery config: %w", err)
+	}
+
+	return conn.Publish(ctx, ent.discoveryTopic, payload, qos, true) //nolint:wrapcheck
+}
+
+func (b *Bridge) publishState(ctx context.Context, conn mqttclient.Conn, ent *entity) {
+	stateEntity, _ := ent.Entity.(pluginapi.HomeAssistantStateEntity)
+	logger := b.logger.With(slog.String("entity", ent.Key))
+
+	state, err := stateEntity.State(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get Home Assistant entity state", slog.Any("error", err))
+
+		return
+	}
+
+	if err = conn.Publish(ctx, ent.stateTopic, []byte(state), qos, true); err != nil {
+		logger.ErrorContext(ctx, "failed to publish Home Assistant entity state", slog.Any("error", err))
+	}
+}
+
+func (b *Bridge) press(ctx context.Context, ent *entity) {
+	logger := b.logger.With(slog.String("entity", ent.Key))
+
+	buttonEntity, _ := ent.Entity.(pluginapi.HomeAssistantButtonEntity)
+
+	ctx, cancel := context.WithTimeout(ctx, b.cfg.CommandTimeout)
+	defer cancel()
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.ErrorContext(ctx, "Home Assistant button panicked", slog.Any("panic", rec))
+		}
+	}()
+
+	if err := buttonEntity.Press(ctx); err != nil {
+		logger.ErrorContext(ctx, "Home Assistant button press failed", slog.Any("error", err))
+
+		return
+	}
+
+	logger.InfoContext(ctx, "Home Assistant button pressed")
+}
+
+// load resolves the topics of the registered entities once;
+// entities are registered while plugins are loaded, before the bridge runs.
+func (b *Bridge) load() []*entity {
+	b.once.Do(func() {
+		for _, entry := range b.registry.All() {
+			meta := entry.Entity.Meta()
+			objectID := entry.Plugin.ID.ToSafeName("_") + "_" + meta.ID
+			base := mqttclient.Namespace(entry.Plugin.ID) + "/homeassistant/" + meta.ID
+
+			b.entities = append(b.entities, &entity{
+				HomeAssistantEntityEntry: entry,
+				meta:                     meta,
+				uniqueID:                 b.cfg.NodeID + "_" + objectID,
+				discoveryTopic: fmt.Sprintf(
+					"%s/%s/%s/%s/config",
+					b.cfg.DiscoveryPrefix,
+					meta.Component,
+					b.cfg.NodeID,
+					objectID,
+				),
+				stateTopic:   base + "/state",
+				commandTopic: base + "/press",
+			})
+		}
+	})
+
+	return b.entities
+}
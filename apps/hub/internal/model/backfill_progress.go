@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// BackfillProgress represents a period that has been successfully backfilled for a cron job.
+type BackfillProgress struct {
+	JobID       string    `db:"job_id"`
+	PeriodStart time.Time `db:"period_start"`
+	CompletedAt time.Time `db:"completed_at"`
+}
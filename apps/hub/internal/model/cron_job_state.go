@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// CronJobState represents the execution health of a cron job.
+type CronJobState struct {
+	JobID               string     `db:"job_id"`
+	ConsecutiveFailures int        `db:"consecutive_failures"`
+	LastSuccessAt       *time.Time `db:"last_success_at"`
+	LastFailureAt       *time.Time `db:"last_failure_at"`
+	LastError           *string    `db:"last_error"`
+	AlertedAt           *time.Time `db:"alerted_at"`
+	CreatedAt           time.Time  `db:"created_at"`
+	UpdatedAt           time.Time  `db:"updated_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Device represents a device allowed to connect to the MQTT broker on behalf of a plugin.
+// Topics are topic filters relative to the plugin namespace; LastSeenAt is the time of the last
+// successful authentication.
+type Device struct {
+	ID           string         `db:"id"`
+	Name         string         `db:"name"`
+	PluginID     string         `db:"plugin_id"`
+	PasswordHash string         `db:"password_hash"`
+	Topics       pq.StringArray `db:"topics"`
+	LastSeenAt   *time.Time     `db:"last_seen_at"`
+	RevokedAt    *time.Time     `db:"revoked_at"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+}
@@ -0,0 +1,2 @@
+// Package model defines the core domain entities persisted by the hub.
+package model
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// MQTTDeadLetter represents an MQTT message whose subscriber failed to handle it.
+// Subscription is the effective topic pattern the subscriber is registered under.
+type MQTTDeadLetter struct {
+	ID            string    `db:"id"`
+	Subscription  string    `db:"subscription"`
+	SubscriberID  string    `db:"subscriber_id"`
+	Topic         string    `db:"topic"`
+	RelativeTopic string    `db:"relative_topic"`
+	Payload       []byte    `db:"payload"`
+	LastError     string    `db:"last_error"`
+	Attempts      int       `db:"attempts"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// NotificationDeliveryStatus represents the lifecycle state of a notification delivery.
+type NotificationDeliveryStatus string
+
+// Notification delivery statuses.
+const (
+	NotificationDeliveryStatusPending   NotificationDeliveryStatus = "pending"
+	NotificationDeliveryStatusRunning   NotificationDeliveryStatus = "running"
+	NotificationDeliveryStatusDelivered NotificationDeliveryStatus = "delivered"
+	NotificationDeliveryStatusFailed    NotificationDeliveryStatus = "failed"
+)
+
+// NotificationMessage represents a notification enqueued in the outbox for a channel.
+// Message holds the JSON-encoded notifierapi.Message.
+type NotificationMessage struct {
+	ID        string    `db:"id"`
+	Channel   string    `db:"channel"`
+	Message   []byte    `db:"message"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// NotificationDelivery represents the delivery of an outbox message through one transport.
+// Fallback deliveries are created once all primary deliveries of the message failed.
+type NotificationDelivery struct {
+	ID          string                     `db:"id"`
+	MessageID   string                     `db:"message_id"`
+	Transport   string                     `db:"transport"`
+	Fallback    bool                       `db:"fallback"`
+	Status      NotificationDeliveryStatus `db:"status"`
+	Attempts    int                        `db:"attempts"`
+	RunAt       time.Time                  `db:"run_at"`
+	LockedUntil *time.Time                 `db:"locked_until"`
+	LastError   *string                    `db:"last_error"`
+	DeliveredAt *time.Time                 `db:"delivered_at"`
+	CreatedAt   time.Time                  `db:"created_at"`
+	UpdatedAt   time.Time                  `db:"updated_at"`
+}
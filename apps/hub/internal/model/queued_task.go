@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// QueuedTaskStatus represents the lifecycle state of a queued task.
+type QueuedTaskStatus string
+
+// Queued task statuses.
+const (
+	QueuedTaskStatusPending   QueuedTaskStatus = "pending"
+	QueuedTaskStatusRunning   QueuedTaskStatus = "running"
+	QueuedTaskStatusSucceeded QueuedTaskStatus = "succeeded"
+	QueuedTaskStatusFailed    QueuedTaskStatus = "failed"
+)
+
+// QueuedTask represents a task enqueued by a plugin for background processing.
+type QueuedTask struct {
+	ID          string           `db:"id"`
+	Type        string           `db:"type"`
+	Payload     []byte           `db:"payload"`
+	Status      QueuedTaskStatus `db:"status"`
+	UniqueKey   *string          `db:"unique_key"`
+	Attempts    int              `db:"attempts"`
+	MaxAttempts int              `db:"max_attempts"`
+	Backoff     time.Duration    `db:"backoff_ns"`
+	RunAt       time.Time        `db:"run_at"`
+	LockedUntil *time.Time       `db:"locked_until"`
+	LastError   *string          `db:"last_error"`
+	CompletedAt *time.Time       `db:"completed_at"`
+	CreatedAt   time.Time        `db:"created_at"`
+	UpdatedAt   time.Time        `db:"updated_at"`
+}
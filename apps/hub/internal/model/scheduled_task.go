@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// ScheduledTask represents a delayed or recurring task scheduled by a plugin at runtime.
+type ScheduledTask struct {
+	ID          string        `db:"id"`
+	Handler     string        `db:"handler"`
+	Key         *string       `db:"key"`
+	Payload     []byte        `db:"payload"`
+	RunAt       time.Time     `db:"run_at"`
+	Every       time.Duration `db:"every_ns"`
+	LockedUntil *time.Time    `db:"locked_until"`
+	RunCount    int           `db:"run_count"`
+	LastRunAt   *time.Time    `db:"last_run_at"`
+	LastError   *string       `db:"last_error"`
+	Attempts    int           `db:"attempts"`
+	FailedAt    *time.Time    `db:"failed_at"`
+	CreatedAt   time.Time     `db:"created_at"`
+	UpdatedAt   time.Time     `db:"updated_at"`
+}
@@ -0,0 +1,239 @@
+package mqttbroker
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"sync"
+
+	mqtt "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+)
+
+// Credentials authenticates clients of the embedded broker.
+type Credentials interface {
+	// Authenticate returns the topic filters the client may publish and subscribe to,
+	// or ErrInvalidMQTTCredentials if the username or password is wrong.
+	Authenticate(ctx context.Context, username string, password []byte) ([]string, error)
+	// Grants returns the current topic filters of an authenticated client,
+	// or ErrInvalidMQTTCredentials if the username is no longer valid, e.g. revoked.
+	Grants(ctx context.Context, username string) ([]string, error)
+}
+
+// StaticCredentials authenticates clients against the users of the embedded broker configuration.
+type StaticCredentials struct {
+	users map[string]config.MQTTEmbeddedUser
+}
+
+var _ Credentials = (*StaticCredentials)(nil)
+
+// NewStaticCredentials creates a new StaticCredentials.
+func NewStaticCredentials(cfg *config.Config) *StaticCredentials {
+	users := make(map[string]config.MQTTEmbeddedUser, len(cfg.MQTT.Embedded.Users))
+	for _, user := range cfg.MQTT.Embedded.Users {
+		users[user.Username] = user
+	}
+
+	return &StaticCredentials{users: users}
+}
+
+// Authenticate checks the password of a configured user.
+func (c *StaticCredentials) Authenticate(
+	_ context.Context,
+	username string,
+	password []byte,
+) ([]string, error) {
+	user, ok := c.users[username]
+	if !ok || subtle.ConstantTimeCompare([]byte(user.Password), password) != 1 {
+		return nil, errs.ErrInvalidMQTTCredentials
+	}
+
+	return user.Topics, nil
+}
+
+// Grants returns the topic filters of a configured user.
+func (c *StaticCredentials) Grants(_ context.Context, username string) ([]string, error) {
+	user, ok := c.users[username]
+	if !ok {
+		return nil, errs.ErrInvalidMQTTCredentials
+	}
+
+	return user.Topics, nil
+}
+
+// CredentialChain authenticates clients against each of its Credentials in turn,
+// until one of them accepts the client.
+type CredentialChain []Credentials
+
+var _ Credentials = CredentialChain(nil)
+
+// Authenticate returns the topic filters granted by the first Credentials accepting the client.
+func (c CredentialChain) Authenticate(
+	ctx context.Context,
+	username string,
+	password []byte,
+) ([]string, error) {
+	for _, credentials := range c {
+		topics, err := credentials.Authenticate(ctx, username, password)
+		if errors.Is(err, errs.ErrInvalidMQTTCredentials) {
+			continue
+		}
+
+		return topics, err //nolint:wrapcheck
+	}
+
+	return nil, errs.ErrInvalidMQTTCredentials
+}
+
+// Grants returns the topic filters granted by the first Credentials that knows the client.
+func (c CredentialChain) Grants(ctx context.Context, username string) ([]string, error) {
+	for _, credentials := range c {
+		topics, err := credentials.Grants(ctx, username)
+		if errors.Is(err, errs.ErrInvalidMQTTCredentials) {
+			continue
+		}
+
+		return topics, err //nolint:wrapcheck
+	}
+
+	return nil, errs.ErrInvalidMQTTCredentials
+}
+
+// grant holds the topic filters granted to a connected client.
+type grant struct {
+	username string
+	topics   []string
+}
+
+// authHook authenticates connecting clients and checks every publish and subscribe
+// against the topic filters granted to the client. The in-process client is trusted.
+type authHook struct {
+	mqtt.HookBase
+
+	logger      *slog.Logger
+	credentials Credentials
+
+	mu     sync.RWMutex
+	grants map[*mqtt.Client]grant
+}
+
+func newAuthHook(logger *slog.Logger, credentials Credentials) *authHook {
+	return &authHook{
+		logger:      logger,
+		credentials: credentials,
+		grants:      make(map[*mqtt.Client]grant),
+	}
+}
+
+// ID returns the ID of the hook.
+func (h *authHook) ID() string {
+	return "maroid-auth"
+}
+
+// Provides indicates which hook methods this hook provides.
+func (h *authHook) Provides(b byte) bool {
+	return bytes.Contains([]byte{
+		mqtt.OnConnectAuthenticate,
+		mqtt.OnACLCheck,
+		mqtt.OnDisconnect,
+	}, []byte{b})
+}
+
+// OnConnectAuthenticate authenticates the client by its username and password.
+func (h *authHook) OnConnectAuthenticate(cl *mqtt.Client, pk packets.Packet) bool {
+	if cl.Net.Inline {
+		return true
+	}
+
+	username := string(pk.Connect.Username)
+	logger := h.logger.With(slog.String("client_id", cl.ID), slog.String("username", username))
+
+	topics, err := h.credentials.Authenticate(context.Background(), username, pk.Connect.Password)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidMQTTCredentials) {
+			logger.Warn("mqtt client authentication failed")
+		} else {
+			logger.Error("failed to authenticate mqtt client", slog.Any("error", err))
+		}
+
+		return false
+	}
+
+	h.mu.Lock()
+	h.grants[cl] = grant{username: username, topics: topics}
+	h.mu.Unlock()
+
+	return true
+}
+
+// OnACLCheck allows a topic, or a subscription filter, covered by a filter granted to the client.
+func (h *authHook) OnACLCheck(cl *mqtt.Client, topic string, _ bool) bool {
+	if cl.Net.Inline {
+		return true
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, granted := range h.grants[cl].topics {
+		if mqttclient.FilterCovers(granted, topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OnDisconnect forgets the grants of the disconnected client.
+func (h *authHook) OnDisconnect(cl *mqtt.Client, _ error, _ bool) {
+	h.mu.Lock()
+	delete(h.grants, cl)
+	h.mu.Unlock()
+}
+
+// refreshGrants re-reads the grants of every connected client. Clients whose credentials are
+// no longer valid lose their grants and are disconnected; on other errors the grants are kept.
+func (h *authHook) refreshGrants(ctx context.Context, server *mqtt.Server) {
+	h.mu.RLock()
+	clients := make(map[*mqtt.Client]string, len(h.grants))
+
+	for cl, granted := range h.grants {
+		clients[cl] = granted.username
+	}
+	h.mu.RUnlock()
+
+	for cl, username := range clients {
+		logger := h.logger.With(slog.String("client_id", cl.ID), slog.String("username", username))
+
+		topics, err := h.credentials.Grants(ctx, username)
+		if err != nil && !errors.Is(err, errs.ErrInvalidMQTTCredentials) {
+			if ctx.Err() == nil {
+				logger.ErrorContext(ctx, "failed to refresh mqtt client grants", slog.Any("error", err))
+			}
+
+			continue
+		}
+
+		h.mu.Lock()
+		if _, ok := h.grants[cl]; ok {
+			if err != nil {
+				delete(h.grants, cl)
+			} else {
+				h.grants[cl] = grant{username: username, topics: topics}
+			}
+		}
+		h.mu.Unlock()
+
+		if err != nil {
+			logger.WarnContext(ctx, "disconnecting mqtt client with revoked credentials")
+
+			_ = server.DisconnectClient(cl, packets.ErrNotAuthorized)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Package mqttbroker runs an embedded MQTT broker for single-node deployments,
+// so that devices can reach the hub without an external broker.
+package mqttbroker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	mqtt "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/listeners"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+)
+
+// Broker is an embedded MQTT broker. It authenticates clients with Credentials and
+// limits every client to the topic filters it was granted; the grants of connected clients
+// are refreshed periodically, so revoking credentials also cuts off live connections.
+// A stopped broker can be started again, e.g. when its worker is restarted.
+type Broker struct {
+	cfg         config.MQTTEmbedded
+	logger      *slog.Logger
+	credentials Credentials
+
+	mu          sync.Mutex
+	server      *mqtt.Server
+	stopRefresh context.CancelFunc
+	refreshing  sync.WaitGroup
+}
+
+// New creates a new Broker.
+func New(cfg *config.Config, logger *slog.Logger, credentials Credentials) *Broker {
+	return &Broker{
+		cfg:         cfg.MQTT.Embedded,
+		logger:      logger.With(slog.String("component", "mqtt_broker")),
+		credentials: credentials,
+	}
+}
+
+// Start starts the listeners and serves clients in the background.
+func (b *Broker) Start() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.server != nil {
+		return nil
+	}
+
+	server := mqtt.New(&mqtt.Options{
+		InlineClient: true,
+		Logger:       b.logger,
+	})
+
+	hook := newAuthHook(b.logger, b.credentials)
+
+	err := server.AddHook(hook, nil)
+	if err != nil {
+		return fmt.Errorf("adding auth hook: %w", err)
+	}
+
+	if err = b.addListeners(server); err != nil {
+		return err
+	}
+
+	if err = server.Serve(); err != nil {
+		return fmt.Errorf("starting MQTT broker: %w", err)
+	}
+
+	b.server = server
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.stopRefresh = cancel
+
+	b.refreshing.Go(func() {
+		ticker := time.NewTicker(b.cfg.GrantRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				hook.refreshGrants(ctx, server)
+			}
+		}
+	})
+
+	return nil
+}
+
+// Close disconnects all clients and stops the listeners.
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.server == nil {
+		return nil
+	}
+
+	server := b.server
+	b.server = nil
+
+	b.stopRefresh()
+	b.refreshing.Wait()
+
+	if err := server.Close(); err != nil {
+		return fmt.Errorf("stopping MQTT broker: %w", err)
+	}
+
+	return nil
+}
+
+// Conn returns an in-process connection to the running broker.
+//
+//nolint:ireturn
+func (b *Broker) Conn(callbacks mqttclient.Callbacks) (mqttclient.Conn, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.server == nil {
+		return nil, errs.ErrMQTTBrokerNotRunning
+	}
+
+	return newInlineConn(b.server, callbacks), nil
+}
+
+func (b *Broker) addListeners(server *mqtt.Server) error {
+	listenerConfigs := b.cfg.Listeners
+	if len(listenerConfigs) == 0 {
+		listenerConfigs = []config.MQTTListener{{
+			Type:    config.MQTTListenerTCP,
+			Address: config.MQTTDefaultListenerAddress,
+		}}
+	}
+
+	for i, listenerCfg := range listenerConfigs {
+		tlsConfig, err := newListenerTLSConfig(listenerCfg)
+		if err != nil {
+			return err
+		}
+
+		listenerType := listenerCfg.Type
+		if listenerType == "" {
+			listenerType = config.MQTTListenerTCP
+		}
+
+		options := listeners.Config{
+			ID:        fmt.Sprintf("%s-%d", listenerType, i),
+			Address:   listenerCfg.Address,
+			TLSConfig: tlsConfig,
+		}
+
+		var listener listeners.Listener
+		if listenerType == config.MQTTListenerWebSocket {
+			listener = listeners.NewWebsocket(options)
+		} else {
+			listener = listeners.NewTCP(options)
+		}
+
+		if err = server.AddListener(listener); err != nil {
+			return fmt.Errorf("adding %s listener on %s: %w", listenerType, listenerCfg.Address, err)
+		}
+	}
+
+	return nil
+}
+
+func newListenerTLSConfig(cfg config.MQTTListener) (*tls.Config, error) {
+	if cfg.CertFile == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: loading listener key pair: %w", errs.ErrInvalidMQTTTLSConfig, err)
+	}
+
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}
@@ -0,0 +1,120 @@
+package mqttbroker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mqtt "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+//nolint:gochecknoglobals // inline subscription identifiers must be unique per broker
+var subscriptionIDs atomic.Int64
+
+// inlineConn is an in-process connection to the embedded broker.
+// It is never lost, so the callbacks only see the initial connect.
+type inlineConn struct {
+	server    *mqtt.Server
+	callbacks mqttclient.Callbacks
+
+	mu            sync.Mutex
+	subscriptions map[string]int
+}
+
+var _ mqttclient.Conn = (*inlineConn)(nil)
+
+func newInlineConn(server *mqtt.Server, callbacks mqttclient.Callbacks) *inlineConn {
+	return &inlineConn{
+		server:        server,
+		callbacks:     callbacks,
+		subscriptions: make(map[string]int),
+	}
+}
+
+func (c *inlineConn) Connect(_ context.Context) (bool, error) {
+	if c.callbacks.OnConnect != nil {
+		go c.callbacks.OnConnect()
+	}
+
+	return true, nil
+}
+
+func (c *inlineConn) Subscribe(_ context.Context, subscription mqttclient.Subscription) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if id, ok := c.subscriptions[subscription.Topic]; ok {
+		_ = c.server.Unsubscribe(subscription.Topic, id)
+	}
+
+	id := int(subscriptionIDs.Add(1))
+	handler := subscription.Handler
+
+	err := c.server.Subscribe(
+		subscription.Topic,
+		id,
+		func(_ *mqtt.Client, _ packets.Subscription, pk packets.Packet) {
+			handler(mqttclient.Message{
+				Topic:      pk.TopicName,
+				Payload:    pk.Payload,
+				Properties: propertiesFromPacket(pk.Properties),
+			})
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("subscribing to %s: %w", subscription.Topic, err)
+	}
+
+	c.subscriptions[subscription.Topic] = id
+
+	return nil
+}
+
+func (c *inlineConn) Publish(
+	_ context.Context,
+	topic string,
+	payload []byte,
+	qos byte,
+	retained bool,
+) error {
+	if err := c.server.Publish(topic, payload, retained, qos); err != nil {
+		return fmt.Errorf("publishing to %s: %w", topic, err)
+	}
+
+	return nil
+}
+
+func (c *inlineConn) Disconnect() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for topic, id := range c.subscriptions {
+		_ = c.server.Unsubscribe(topic, id)
+	}
+
+	clear(c.subscriptions)
+}
+
+func propertiesFromPacket(props packets.Properties) pluginapi.MQTTProperties {
+	userProperties := make([]pluginapi.MQTTUserProperty, 0, len(props.User))
+	for _, prop := range props.User {
+		userProperties = append(userProperties, pluginapi.MQTTUserProperty{
+			Key:   prop.Key,
+			Value: prop.Val,
+		})
+	}
+
+	return pluginapi.MQTTProperties{
+		ContentType:     props.ContentType,
+		ResponseTopic:   props.ResponseTopic,
+		CorrelationData: props.CorrelationData,
+		MessageExpiry:   time.Duration(props.MessageExpiryInterval) * time.Second,
+		UserProperties:  userProperties,
+	}
+}
@@ -0,0 +1,206 @@
+// Package mqttclient provides MQTT client helpers shared by the MQTT worker and
+// the publisher exposed to plugins through Host.MQTT().
+package mqttclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// Namespace returns the MQTT topic namespace of a plugin.
+// e.g. "dev.maroid.jasmine" → "dev/maroid/jasmine".
+func Namespace(pluginID *pluginapi.PluginID) string {
+	return strings.ReplaceAll(pluginID.String(), ".", "/")
+}
+
+// ValidateRelativeTopic validates a topic given relative to a plugin namespace.
+// Wildcards are allowed only when allowWildcards is set (i.e. for subscriptions).
+func ValidateRelativeTopic(topic string, allowWildcards bool) error {
+	if topic == "" {
+		return fmt.Errorf("%w: topic must not be empty", errs.ErrInvalidMQTTTopic)
+	}
+
+	if strings.HasPrefix(topic, "/") {
+		return fmt.Errorf("%w: topic must not start with /", errs.ErrInvalidMQTTTopic)
+	}
+
+	if strings.HasPrefix(topic, "$") {
+		return fmt.Errorf(
+			"%w: topic must not start with $ (reserved for broker internals)",
+			errs.ErrInvalidMQTTTopic,
+		)
+	}
+
+	if !allowWildcards && strings.ContainsAny(topic, "+#") {
+		return fmt.Errorf("%w: topic must not contain wildcards", errs.ErrInvalidMQTTTopic)
+	}
+
+	return nil
+}
+
+// FilterCovers reports whether every topic matched by filter is also matched by granted.
+// A topic name is a filter without wildcards.
+func FilterCovers(granted string, filter string) bool {
+	grantedLevels := strings.Split(granted, "/")
+	levels := strings.Split(filter, "/")
+
+	for i, grantedLevel := range grantedLevels {
+		if grantedLevel == "#" {
+			return true
+		}
+
+		if i >= len(levels) || levels[i] == "#" {
+			return false
+		}
+
+		if grantedLevel != "+" && grantedLevel != levels[i] {
+			return false
+		}
+	}
+
+	return len(levels) == len(grantedLevels)
+}
+
+// Message is an MQTT message received on a subscription.
+type Message struct {
+	Topic      string
+	Payload    []byte
+	Properties pluginapi.MQTTProperties
+}
+
+// MessageHandler handles messages received on a subscription.
+// It is called from the client receive loop and must not block for long.
+type MessageHandler func(msg Message)
+
+// Subscription is a topic filter subscription.
+type Subscription struct {
+	Topic   string
+	QoS     byte
+	Handler MessageHandler
+}
+
+// Callbacks are invoked on connection lifecycle events. All of them are optional.
+// OnConnect is called in its own goroutine after every (re)connect, so it may subscribe.
+type Callbacks struct {
+	OnConnect        func()
+	OnConnectionLost func(err error)
+	OnReconnecting   func()
+}
+
+// Will is a last-will message: the broker publishes it when the connection is lost
+// without a clean disconnect.
+type Will struct {
+	Topic    string
+	Payload  []byte
+	QoS      byte
+	Retained bool
+}
+
+// Conn is a broker connection speaking the configured MQTT protocol version.
+// It reconnects automatically once established.
+type Conn interface {
+	// Connect starts connecting and waits up to the connect timeout for the connection.
+	// It returns false if the broker was not reachable in time; connecting is then retried
+	// in the background.
+	Connect(ctx context.Context) (bool, error)
+	// Subscribe subscribes to a topic filter and waits for the broker to acknowledge it.
+	Subscribe(ctx context.Context, subscription Subscription) error
+	// Publish sends a message and waits for the broker to acknowledge it according to the QoS level.
+	Publish(ctx context.Context, topic string, payload []byte, qos byte, retained bool) error
+	// Disconnect closes the connection, giving in-flight work the configured quiesce period.
+	Disconnect()
+}
+
+// New creates a connection for the given role using the configured protocol version and TLS options.
+// The client ID is derived from the configured prefix, the role and the host name.
+// The optional will is registered with the broker on every connect.
+//
+//nolint:ireturn
+func New(
+	cfg config.MQTT,
+	role string,
+	logger *slog.Logger,
+	callbacks Callbacks,
+	will *Will,
+) (Conn, error) {
+	clientID, err := newClientID(cfg.ClientIDPrefix, role)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := newTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Version == config.MQTTVersion5 {
+		return newV5Conn(cfg, clientID, tlsConfig, logger, callbacks, will)
+	}
+
+	return newV3Conn(cfg, clientID, tlsConfig, callbacks, will), nil
+}
+
+func newClientID(prefix string, role string) (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("getting hostname: %w", err)
+	}
+
+	if role == "" {
+		return fmt.Sprintf("%s@%s", prefix, hostname), nil
+	}
+
+	return fmt.Sprintf("%s-%s@%s-%d", prefix, role, hostname, os.Getpid()), nil
+}
+
+// newTLSConfig builds the TLS configuration from the TLS options.
+// It returns nil if no option is set, so that the client defaults apply.
+func newTLSConfig(cfg config.MQTTTLS) (*tls.Config, error) {
+	if cfg == (config.MQTTTLS{}) {
+		return nil, nil //nolint:nilnil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // opt-in for development brokers
+	}
+
+	if cfg.CAFile != "" {
+		caPEM, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading MQTT CA bundle: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf(
+				"%w: no certificates found in %s",
+				errs.ErrInvalidMQTTTLSConfig,
+				cfg.CAFile,
+			)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: loading client certificate: %w", errs.ErrInvalidMQTTTLSConfig, err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,206 @@
+package mqttclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// InlineBroker provides in-process connections to the embedded broker.
+type InlineBroker interface {
+	Conn(callbacks Callbacks) (Conn, error)
+}
+
+// Publisher publishes MQTT messages over a dedicated connection that is
+// established on first use, so it works from any process. When the embedded broker
+// runs in the same process, messages are published to it in-process instead.
+type Publisher struct {
+	cfg    config.MQTT
+	logger *slog.Logger
+	broker InlineBroker
+
+	mu   sync.Mutex
+	conn Conn
+}
+
+// NewPublisher creates a new Publisher. broker is the embedded broker, or nil when it is disabled.
+func NewPublisher(cfg *config.Config, logger *slog.Logger, broker InlineBroker) *Publisher {
+	return &Publisher{
+		cfg: cfg.MQTT,
+		logger: logger.With(
+			slog.String("component", "mqtt"),
+			slog.String("mqtt_client", "publisher"),
+		),
+		broker: broker,
+	}
+}
+
+// Publish sends the payload to the absolute topic and waits for the broker
+// to acknowledge it according to the QoS level. In dry-run mode the message is only logged.
+func (p *Publisher) Publish(
+	ctx context.Context,
+	topic string,
+	payload []byte,
+	qos byte,
+	retained bool,
+) error {
+	logger := p.logger.With(
+		slog.String("topic", topic),
+		slog.Int("qos", int(qos)),
+		slog.Bool("retained", retained),
+	)
+
+	if pluginapi.IsDryRun(ctx) {
+		logger.InfoContext(ctx, "dry run: mqtt message not published", slog.Int("bytes", len(payload)))
+
+		return nil
+	}
+
+	conn, err := p.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = conn.Publish(ctx, topic, payload, qos, retained); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	logger.DebugContext(ctx, "mqtt message published")
+
+	return nil
+}
+
+// Close disconnects the publisher from the broker if it is connected.
+func (p *Publisher) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return
+	}
+
+	p.conn.Disconnect()
+	p.conn = nil
+}
+
+// connect returns an in-process connection when the embedded broker runs in this process.
+// Otherwise it returns the network connection to mqtt.broker or, without it, to a listener
+// of the embedded broker running in another process.
+//
+//nolint:ireturn
+func (p *Publisher) connect(ctx context.Context) (Conn, error) {
+	if p.broker != nil {
+		// In-process connections are not cached, as a restarted broker invalidates them.
+		conn, err := p.broker.Conn(Callbacks{})
+		if !errors.Is(err, errs.ErrMQTTBrokerNotRunning) {
+			return conn, err
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn != nil {
+		return p.conn, nil
+	}
+
+	cfg := p.cfg
+
+	broker, err := BrokerURL(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Broker = broker
+
+	conn, err := New(cfg, "publisher", p.logger, Callbacks{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	connected, err := conn.Connect(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if connected {
+		p.logger.InfoContext(ctx, "connected to MQTT broker", slog.String("broker", cfg.Broker))
+	} else {
+		p.logger.WarnContext(ctx,
+			"MQTT broker not reachable yet, retrying in background",
+			slog.String("broker", cfg.Broker),
+		)
+	}
+
+	p.conn = conn
+
+	return conn, nil
+}
+
+// BrokerURL returns the URL of the configured broker, falling back to the local URL of the
+// embedded broker if none is set.
+func BrokerURL(cfg config.MQTT) (string, error) {
+	if cfg.Broker != "" {
+		return cfg.Broker, nil
+	}
+
+	if !cfg.Embedded.Enabled {
+		return "", fmt.Errorf(
+			"%w: mqtt.broker is not set and the embedded broker is disabled",
+			errs.ErrMQTTBrokerNotConfigured,
+		)
+	}
+
+	return embeddedBrokerURL(cfg.Embedded)
+}
+
+// embeddedBrokerURL returns the local URL of the first TCP listener of the embedded broker,
+// preferring listeners without TLS.
+func embeddedBrokerURL(cfg config.MQTTEmbedded) (string, error) {
+	listeners := cfg.Listeners
+	if len(listeners) == 0 {
+		listeners = []config.MQTTListener{{Address: config.MQTTDefaultListenerAddress}}
+	}
+
+	var selected *config.MQTTListener
+
+	for i, listener := range listeners {
+		if listener.Type != "" && listener.Type != config.MQTTListenerTCP {
+			continue
+		}
+
+		if selected == nil || (selected.CertFile != "" && listener.CertFile == "") {
+			selected = &listeners[i]
+		}
+	}
+
+	if selected == nil {
+		return "", fmt.Errorf(
+			"%w: mqtt.broker is not set and the embedded broker has no TCP listener",
+			errs.ErrMQTTBrokerNotConfigured,
+		)
+	}
+
+	host, port, err := net.SplitHostPort(selected.Address)
+	if err != nil {
+		return "", fmt.Errorf("parsing embedded broker listener address: %w", err)
+	}
+
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+
+	scheme := "tcp"
+	if selected.CertFile != "" {
+		scheme = "tls"
+	}
+
+	return scheme + "://" + net.JoinHostPort(host, port), nil
+}
@@ -0,0 +1,126 @@
+package mqttclient
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+)
+
+// v3Conn is an MQTT v3.1.1 connection.
+type v3Conn struct {
+	cfg    config.MQTT
+	client mqtt.Client
+}
+
+var _ Conn = (*v3Conn)(nil)
+
+func newV3Conn(
+	cfg config.MQTT,
+	clientID string,
+	tlsConfig *tls.Config,
+	callbacks Callbacks,
+	will *Will,
+) *v3Conn {
+	opts := mqtt.NewClientOptions().
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetConnectTimeout(cfg.ConnectTimeout).
+		AddBroker(cfg.Broker).
+		SetClientID(clientID).
+		SetUsername(cfg.User).
+		SetPassword(cfg.Password)
+
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	if will != nil {
+		opts.SetBinaryWill(will.Topic, will.Payload, will.QoS, will.Retained)
+	}
+
+	if callbacks.OnConnect != nil {
+		opts.SetOnConnectHandler(func(_ mqtt.Client) { callbacks.OnConnect() })
+	}
+
+	if callbacks.OnConnectionLost != nil {
+		opts.SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			callbacks.OnConnectionLost(err)
+		})
+	}
+
+	if callbacks.OnReconnecting != nil {
+		opts.SetReconnectingHandler(func(_ mqtt.Client, _ *mqtt.ClientOptions) {
+			callbacks.OnReconnecting()
+		})
+	}
+
+	return &v3Conn{
+		cfg:    cfg,
+		client: mqtt.NewClient(opts),
+	}
+}
+
+func (c *v3Conn) Connect(_ context.Context) (bool, error) {
+	token := c.client.Connect()
+	if !token.WaitTimeout(c.cfg.ConnectTimeout) {
+		return false, nil
+	}
+
+	if err := token.Error(); err != nil {
+		return false, fmt.Errorf("connecting to MQTT broker %s: %w", c.cfg.Broker, err)
+	}
+
+	return true, nil
+}
+
+func (c *v3Conn) Subscribe(ctx context.Context, subscription Subscription) error {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.ConnectTimeout)
+	defer cancel()
+
+	token := c.client.Subscribe(
+		subscription.Topic,
+		subscription.QoS,
+		func(_ mqtt.Client, msg mqtt.Message) {
+			subscription.Handler(Message{
+				Topic:   msg.Topic(),
+				Payload: msg.Payload(),
+			})
+		},
+	)
+
+	return waitToken(ctx, token, "subscribing to topic "+subscription.Topic)
+}
+
+func (c *v3Conn) Publish(
+	ctx context.Context,
+	topic string,
+	payload []byte,
+	qos byte,
+	retained bool,
+) error {
+	token := c.client.Publish(topic, qos, retained, payload)
+
+	return waitToken(ctx, token, "publishing to topic "+topic)
+}
+
+func (c *v3Conn) Disconnect() {
+	c.client.Disconnect(c.cfg.DisconnectQuiesce)
+}
+
+func waitToken(ctx context.Context, token mqtt.Token, action string) error {
+	select {
+	case <-token.Done():
+	case <-ctx.Done():
+		return fmt.Errorf("%s: %w", action, ctx.Err())
+	}
+
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("%s: %w", action, err)
+	}
+
+	return nil
+}
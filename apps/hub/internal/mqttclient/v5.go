@@ -0,0 +1,311 @@
+package mqttclient
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+const (
+	v5KeepAlive = 30 // seconds
+	// v5SubackFailure is the lowest SUBACK reason code indicating a rejected subscription.
+	v5SubackFailure = 0x80
+)
+
+// v5Conn is an MQTT v5 connection.
+// Incoming messages are routed to subscription handlers by topic filter.
+type v5Conn struct {
+	cfg       config.MQTT
+	logger    *slog.Logger
+	clientCfg autopaho.ClientConfig
+	router    *paho.StandardRouter
+
+	mu             sync.Mutex
+	manager        *autopaho.ConnectionManager
+	lastErr        error
+	userProperties paho.UserProperties
+}
+
+var _ Conn = (*v5Conn)(nil)
+
+func newV5Conn(
+	cfg config.MQTT,
+	clientID string,
+	tlsConfig *tls.Config,
+	logger *slog.Logger,
+	callbacks Callbacks,
+	will *Will,
+) (*v5Conn, error) {
+	serverURL, err := url.Parse(cfg.Broker)
+	if err != nil {
+		return nil, fmt.Errorf("parsing MQTT broker URL: %w", err)
+	}
+
+	conn := &v5Conn{
+		cfg:    cfg,
+		logger: logger,
+		router: paho.NewStandardRouter(),
+	}
+
+	for key, value := range cfg.V5.UserProperties {
+		conn.userProperties.Add(key, value)
+	}
+
+	conn.clientCfg = autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{serverURL},
+		TlsCfg:                        tlsConfig,
+		KeepAlive:                     v5KeepAlive,
+		CleanStartOnInitialConnection: cfg.V5.SessionExpiry == 0,
+		SessionExpiryInterval:         seconds(cfg.V5.SessionExpiry),
+		ConnectTimeout:                cfg.ConnectTimeout,
+		ConnectUsername:               cfg.User,
+		ConnectPassword:               []byte(cfg.Password),
+		ConnectPacketBuilder: func(connect *paho.Connect, _ *url.URL) (*paho.Connect, error) {
+			if len(conn.userProperties) == 0 {
+				return connect, nil
+			}
+
+			if connect.Properties == nil {
+				connect.Properties = new(paho.ConnectProperties)
+			}
+
+			connect.Properties.User = conn.userProperties
+
+			return connect, nil
+		},
+		OnConnectionUp: func(_ *autopaho.ConnectionManager, _ *paho.Connack) {
+			if callbacks.OnConnect != nil {
+				go callbacks.OnConnect()
+			}
+		},
+		OnConnectionDown: func() bool {
+			if callbacks.OnConnectionLost != nil {
+				callbacks.OnConnectionLost(conn.takeLastErr())
+			}
+
+			if callbacks.OnReconnecting != nil {
+				callbacks.OnReconnecting()
+			}
+
+			return true
+		},
+		OnConnectError: func(err error) {
+			logger.Warn("mqtt connection attempt failed", slog.Any("error", err))
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: clientID,
+			OnPublishReceived: []func(paho.PublishReceived) (bool, error){
+				func(received paho.PublishReceived) (bool, error) {
+					conn.router.Route(received.Packet.Packet())
+
+					return true, nil
+				},
+			},
+			OnClientError: conn.setLastErr,
+			OnServerDisconnect: func(disconnect *paho.Disconnect) {
+				reason := ""
+				if disconnect.Properties != nil {
+					reason = disconnect.Properties.ReasonString
+				}
+
+				conn.setLastErr(fmt.Errorf(
+					"%w: reason code %#x %s",
+					errs.ErrMQTTServerDisconnect,
+					disconnect.ReasonCode,
+					reason,
+				))
+			},
+		},
+	}
+
+	if will != nil {
+		conn.clientCfg.WillMessage = &paho.WillMessage{
+			Topic:   will.Topic,
+			Payload: will.Payload,
+			QoS:     will.QoS,
+			Retain:  will.Retained,
+		}
+	}
+
+	return conn, nil
+}
+
+func (c *v5Conn) Connect(ctx context.Context) (bool, error) {
+	// The connection outlives ctx; it is closed by Disconnect.
+	manager, err := autopaho.NewConnection(context.WithoutCancel(ctx), c.clientCfg)
+	if err != nil {
+		return false, fmt.Errorf("connecting to MQTT broker %s: %w", c.cfg.Broker, err)
+	}
+
+	c.mu.Lock()
+	c.manager = manager
+	c.mu.Unlock()
+
+	awaitCtx, cancel := context.WithTimeout(ctx, c.cfg.ConnectTimeout)
+	defer cancel()
+
+	return manager.AwaitConnection(awaitCtx) == nil, nil
+}
+
+func (c *v5Conn) Subscribe(ctx context.Context, subscription Subscription) error {
+	manager, err := c.connectionManager()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.ConnectTimeout)
+	defer cancel()
+
+	// Handlers are replaced, not added, as subscriptions are renewed after every reconnect.
+	c.router.UnregisterHandler(subscription.Topic)
+	c.router.RegisterHandler(subscription.Topic, func(publish *paho.Publish) {
+		subscription.Handler(Message{
+			Topic:      publish.Topic,
+			Payload:    publish.Payload,
+			Properties: propertiesFromPublish(publish),
+		})
+	})
+
+	suback, err := manager.Subscribe(ctx, &paho.Subscribe{
+		Subscriptions: []paho.SubscribeOptions{
+			{Topic: subscription.Topic, QoS: subscription.QoS},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("subscribing to topic %s: %w", subscription.Topic, err)
+	}
+
+	if len(suback.Reasons) > 0 && suback.Reasons[0] >= v5SubackFailure {
+		return fmt.Errorf(
+			"subscribing to topic %s: %w: reason code %#x",
+			subscription.Topic,
+			errs.ErrMQTTSubscriptionRejected,
+			suback.Reasons[0],
+		)
+	}
+
+	return nil
+}
+
+func (c *v5Conn) Publish(
+	ctx context.Context,
+	topic string,
+	payload []byte,
+	qos byte,
+	retained bool,
+) error {
+	manager, err := c.connectionManager()
+	if err != nil {
+		return err
+	}
+
+	properties := &paho.PublishProperties{User: c.userProperties}
+
+	if c.cfg.V5.MessageExpiry > 0 {
+		expiry := seconds(c.cfg.V5.MessageExpiry)
+		properties.MessageExpiry = &expiry
+	}
+
+	_, err = manager.Publish(ctx, &paho.Publish{
+		Topic:      topic,
+		QoS:        qos,
+		Retain:     retained,
+		Payload:    payload,
+		Properties: properties,
+	})
+	if err != nil {
+		return fmt.Errorf("publishing to topic %s: %w", topic, err)
+	}
+
+	return nil
+}
+
+func (c *v5Conn) Disconnect() {
+	c.mu.Lock()
+	manager := c.manager
+	c.manager = nil
+	c.mu.Unlock()
+
+	if manager == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Duration(c.cfg.DisconnectQuiesce)*time.Millisecond,
+	)
+	defer cancel()
+
+	if err := manager.Disconnect(ctx); err != nil {
+		c.logger.Warn("mqtt disconnect did not complete", slog.Any("error", err))
+	}
+}
+
+func (c *v5Conn) connectionManager() (*autopaho.ConnectionManager, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.manager == nil {
+		return nil, errs.ErrMQTTNotConnected
+	}
+
+	return c.manager, nil
+}
+
+func (c *v5Conn) setLastErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastErr = err
+}
+
+func (c *v5Conn) takeLastErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	err := c.lastErr
+	c.lastErr = nil
+
+	return err
+}
+
+func propertiesFromPublish(publish *paho.Publish) pluginapi.MQTTProperties {
+	if publish.Properties == nil {
+		return pluginapi.MQTTProperties{}
+	}
+
+	props := pluginapi.MQTTProperties{
+		ContentType:     publish.Properties.ContentType,
+		ResponseTopic:   publish.Properties.ResponseTopic,
+		CorrelationData: publish.Properties.CorrelationData,
+	}
+
+	if publish.Properties.MessageExpiry != nil {
+		props.MessageExpiry = time.Duration(*publish.Properties.MessageExpiry) * time.Second
+	}
+
+	for _, property := range publish.Properties.User {
+		props.UserProperties = append(props.UserProperties, pluginapi.MQTTUserProperty{
+			Key:   property.Key,
+			Value: property.Value,
+		})
+	}
+
+	return props
+}
+
+// seconds converts a configured duration to the seconds used by MQTT v5 expiry intervals.
+func seconds(duration time.Duration) uint32 {
+	return uint32(duration / time.Second) //nolint:gosec // configured durations are small
+}
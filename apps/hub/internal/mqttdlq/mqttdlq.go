@@ -0,0 +1,253 @@
+// Package mqttdlq persists MQTT messages that subscribers failed to handle
+// and re-dispatches them once the cause of the failure is fixed.
+package mqttdlq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/database"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttsubscriber"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/apps/hub/internal/repository"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// Store is the MQTT dead-letter store.
+type Store struct {
+	cfg      config.MQTT
+	logger   *slog.Logger
+	db       *sqlx.DB
+	registry *registry.MQTTSubscriberRegistry
+}
+
+// New creates a new Store.
+func New(
+	cfg *config.Config,
+	logger *slog.Logger,
+	db *sqlx.DB,
+	mqttSubscriberRegistry *registry.MQTTSubscriberRegistry,
+) *Store {
+	return &Store{
+		cfg: cfg.MQTT,
+		logger: logger.With(
+			slog.String("component", "mqttdlq"),
+		),
+		db:       db,
+		registry: mqttSubscriberRegistry,
+	}
+}
+
+// Record persists a message the subscriber registered under subscription failed to handle.
+func (s *Store) Record(ctx context.Context, entity *model.MQTTDeadLetter) error {
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		id, err := repository.NewMQTTDeadLetter(tx).Insert(ctx, entity)
+		if err != nil {
+			return err
+		}
+
+		entity.ID = id
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("recording MQTT dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the dead-lettered message with the given ID.
+func (s *Store) Get(ctx context.Context, id string) (*model.MQTTDeadLetter, error) {
+	var entity *model.MQTTDeadLetter
+
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		entity, err = repository.NewMQTTDeadLetter(tx).Get(ctx, id)
+
+		return err
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return entity, nil
+}
+
+// List returns up to limit dead-lettered messages, oldest first,
+// optionally only the ones of the given subscriber.
+func (s *Store) List(
+	ctx context.Context,
+	subscriberID string,
+	limit int,
+) ([]model.MQTTDeadLetter, error) {
+	var entities []model.MQTTDeadLetter
+
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		entities, err = repository.NewMQTTDeadLetter(tx).List(ctx, subscriberID, limit)
+
+		return err
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return entities, nil
+}
+
+// Replay re-dispatches the dead-lettered message with the given ID to its subscriber.
+// The message is removed once handled; otherwise its attempt count is increased and
+// an error wrapping ErrMQTTDeadLetterReplayFailed is returned.
+func (s *Store) Replay(ctx context.Context, id string) error {
+	entity, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.replay(ctx, entity)
+}
+
+// ReplayAll re-dispatches up to limit dead-lettered messages, optionally only the ones of
+// the given subscriber, and returns the number of messages handled and failed again.
+func (s *Store) ReplayAll(
+	ctx context.Context,
+	subscriberID string,
+	limit int,
+) (int, int, error) {
+	entities, err := s.List(ctx, subscriberID, limit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var replayed, failed int
+
+	for i := range entities {
+		err = s.replay(ctx, &entities[i])
+
+		switch {
+		case err == nil:
+			replayed++
+		case errors.Is(err, errs.ErrMQTTDeadLetterReplayFailed):
+			failed++
+		default:
+			return replayed, failed, err
+		}
+	}
+
+	return replayed, failed, nil
+}
+
+// Delete removes the dead-lettered message with the given ID.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		repo := repository.NewMQTTDeadLetter(tx)
+
+		if _, err := repo.Get(ctx, id); err != nil {
+			return err
+		}
+
+		return repo.Delete(ctx, id)
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// Purge removes dead-lettered messages created before the given time, optionally only
+// the ones of the given subscriber, and returns their count.
+func (s *Store) Purge(ctx context.Context, subscriberID string, before time.Time) (int64, error) {
+	var count int64
+
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		count, err = repository.NewMQTTDeadLetter(tx).Purge(ctx, subscriberID, before)
+
+		return err
+	})
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	return count, nil
+}
+
+func (s *Store) replay(ctx context.Context, entity *model.MQTTDeadLetter) error {
+	sub, ok := s.registry.Get(entity.Subscription)
+	if !ok {
+		return fmt.Errorf("%w: %s", errs.ErrMQTTSubscriberNotFound, entity.Subscription)
+	}
+
+	logger := s.logger.With(
+		slog.String("dead_letter_id", entity.ID),
+		slog.String("subscriber_id", entity.SubscriberID),
+		slog.String("topic", entity.Topic),
+	)
+
+	handleErr := s.handle(ctx, sub, entity)
+
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		repo := repository.NewMQTTDeadLetter(tx)
+
+		if handleErr != nil {
+			return repo.RecordFailure(ctx, entity.ID, handleErr.Error())
+		}
+
+		return repo.Delete(ctx, entity.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("updating MQTT dead letter: %w", err)
+	}
+
+	if handleErr != nil {
+		logger.WarnContext(ctx, "mqtt dead letter replay failed", slog.Any("error", handleErr))
+
+		return fmt.Errorf("%w: %w", errs.ErrMQTTDeadLetterReplayFailed, handleErr)
+	}
+
+	logger.InfoContext(ctx, "mqtt dead letter replayed")
+
+	return nil
+}
+
+func (s *Store) handle(
+	ctx context.Context,
+	sub pluginapi.MQTTSubscriber,
+	entity *model.MQTTDeadLetter,
+) (err error) {
+	if s.cfg.Dispatch.HandlerTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Dispatch.HandlerTimeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("%w: %v", errs.ErrMQTTSubscriberPanicked, rec)
+		}
+	}()
+
+	// MQTT v5 properties are not persisted, so they are empty on replay.
+	//nolint:wrapcheck
+	return mqttsubscriber.Deliver(
+		ctx,
+		sub,
+		entity.RelativeTopic,
+		entity.Payload,
+		pluginapi.MQTTProperties{},
+	)
+}
@@ -0,0 +1,167 @@
+package mqttsubscriber
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// senmlRelativeTimeLimit is the threshold below which SenML times are relative to now (RFC 8428).
+const senmlRelativeTimeLimit = 1 << 28
+
+type decodeFunc func(payload []byte, value any) error
+
+//nolint:gochecknoglobals
+var decoders = map[pluginapi.MQTTCodec]decodeFunc{
+	pluginapi.MQTTCodecJSON:     json.Unmarshal,
+	pluginapi.MQTTCodecCBOR:     cbor.Unmarshal,
+	pluginapi.MQTTCodecSenML:    decodeSenML,
+	pluginapi.MQTTCodecProtobuf: decodeProtobuf,
+}
+
+// ValidateCodec checks that the codec is supported; an empty codec selects JSON.
+func ValidateCodec(codec pluginapi.MQTTCodec) error {
+	if _, ok := decoders[effectiveCodec(codec)]; !ok {
+		return fmt.Errorf("%w: %s", errs.ErrUnsupportedMQTTCodec, codec)
+	}
+
+	return nil
+}
+
+func decode(codec pluginapi.MQTTCodec, payload []byte, value any) error {
+	codec = effectiveCodec(codec)
+
+	decoder, ok := decoders[codec]
+	if !ok {
+		return fmt.Errorf("%w: %s", errs.ErrUnsupportedMQTTCodec, codec)
+	}
+
+	if err := decoder(payload, value); err != nil {
+		return fmt.Errorf("decoding %s payload: %w", codec, err)
+	}
+
+	return nil
+}
+
+func effectiveCodec(codec pluginapi.MQTTCodec) pluginapi.MQTTCodec {
+	if codec == "" {
+		return pluginapi.MQTTCodecJSON
+	}
+
+	return codec
+}
+
+func decodeProtobuf(payload []byte, value any) error {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%w: %T is not a proto.Message", errs.ErrInvalidMQTTCodecValue, value)
+	}
+
+	return proto.Unmarshal(payload, message) //nolint:wrapcheck
+}
+
+type senmlRecord struct {
+	BaseName    string   `json:"bn"`
+	BaseTime    float64  `json:"bt"`
+	BaseUnit    string   `json:"bu"`
+	BaseValue   *float64 `json:"bv"`
+	BaseSum     *float64 `json:"bs"`
+	Name        string   `json:"n"`
+	Unit        string   `json:"u"`
+	Value       *float64 `json:"v"`
+	StringValue *string  `json:"vs"`
+	BoolValue   *bool    `json:"vb"`
+	DataValue   *string  `json:"vd"`
+	Sum         *float64 `json:"s"`
+	Time        float64  `json:"t"`
+}
+
+// decodeSenML decodes a SenML JSON pack and resolves its records (RFC 8428, section 4.6):
+// base fields carry over to the following records until they are set again.
+func decodeSenML(payload []byte, value any) error {
+	pack, ok := value.(*pluginapi.SenMLPack)
+	if !ok {
+		return fmt.Errorf("%w: %T is not a *pluginapi.SenMLPack", errs.ErrInvalidMQTTCodecValue, value)
+	}
+
+	var records []senmlRecord
+	if err := json.Unmarshal(payload, &records); err != nil {
+		return fmt.Errorf("parsing SenML pack: %w", err)
+	}
+
+	var base senmlRecord
+
+	now := time.Now()
+	resolved := make(pluginapi.SenMLPack, 0, len(records))
+
+	for _, record := range records {
+		if record.BaseName != "" {
+			base.BaseName = record.BaseName
+		}
+
+		if record.BaseTime != 0 {
+			base.BaseTime = record.BaseTime
+		}
+
+		if record.BaseUnit != "" {
+			base.BaseUnit = record.BaseUnit
+		}
+
+		if record.BaseValue != nil {
+			base.BaseValue = record.BaseValue
+		}
+
+		if record.BaseSum != nil {
+			base.BaseSum = record.BaseSum
+		}
+
+		name := base.BaseName + record.Name
+		if name == "" {
+			return fmt.Errorf("%w: SenML record without a name", errs.ErrInvalidMQTTPayload)
+		}
+
+		resolved = append(resolved, pluginapi.SenMLRecord{
+			Name:        name,
+			Unit:        cmp.Or(record.Unit, base.BaseUnit),
+			Time:        senmlTime(base.BaseTime+record.Time, now),
+			Value:       addBase(record.Value, base.BaseValue),
+			StringValue: record.StringValue,
+			BoolValue:   record.BoolValue,
+			DataValue:   record.DataValue,
+			Sum:         addBase(record.Sum, base.BaseSum),
+		})
+	}
+
+	*pack = resolved
+
+	return nil
+}
+
+func senmlTime(seconds float64, now time.Time) time.Time {
+	whole, fraction := math.Modf(seconds)
+	offset := time.Duration(whole)*time.Second + time.Duration(fraction*float64(time.Second))
+
+	if seconds < senmlRelativeTimeLimit {
+		return now.Add(offset)
+	}
+
+	return time.Unix(0, 0).Add(offset)
+}
+
+func addBase(value *float64, base *float64) *float64 {
+	if value == nil || base == nil {
+		return value
+	}
+
+	sum := *value + *base
+
+	return &sum
+}
@@ -0,0 +1,71 @@
+package mqttsubscriber
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// Deliver hands a message received on a relative topic to the subscriber.
+// Typed subscribers get the named topic parameters and the decoded payload; a message that
+// cannot be decoded is not delivered and an error wrapping ErrMQTTMessageDecode is returned.
+func Deliver(
+	ctx context.Context,
+	sub pluginapi.MQTTSubscriber,
+	topic string,
+	payload []byte,
+	props pluginapi.MQTTProperties,
+) error {
+	switch typed := sub.(type) {
+	case pluginapi.MQTTTypedSubscriber:
+		msg, err := decodeMessage(typed, topic, payload, props)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errs.ErrMQTTMessageDecode, err)
+		}
+
+		return typed.HandleMessage(ctx, msg) //nolint:wrapcheck
+	case pluginapi.MQTTPropertiesSubscriber:
+		return typed.HandleWithProperties(ctx, topic, payload, props) //nolint:wrapcheck
+	default:
+		return sub.Handle(ctx, topic, payload) //nolint:wrapcheck
+	}
+}
+
+func decodeMessage(
+	sub pluginapi.MQTTTypedSubscriber,
+	topic string,
+	payload []byte,
+	props pluginapi.MQTTProperties,
+) (pluginapi.MQTTMessage, error) {
+	meta := sub.Meta()
+
+	pattern, err := ParseTopic(meta.Topic)
+	if err != nil {
+		return pluginapi.MQTTMessage{}, err
+	}
+
+	params, ok := pattern.Match(topic)
+	if !ok {
+		return pluginapi.MQTTMessage{}, fmt.Errorf(
+			"%w: topic %s does not match %s",
+			errs.ErrInvalidMQTTPayload,
+			topic,
+			meta.Topic,
+		)
+	}
+
+	value := sub.NewValue()
+	if err = decode(meta.Codec, payload, value); err != nil {
+		return pluginapi.MQTTMessage{}, err
+	}
+
+	return pluginapi.MQTTMessage{
+		Topic:      topic,
+		Params:     params,
+		Payload:    payload,
+		Value:      value,
+		Properties: props,
+	}, nil
+}
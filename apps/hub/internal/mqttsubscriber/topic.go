@@ -0,0 +1,99 @@
+// Package mqttsubscriber delivers MQTT messages to plugin subscribers, extracting named topic
+// parameters and decoding payloads with the codec the subscriber declares.
+package mqttsubscriber
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+)
+
+const (
+	singleLevelWildcard = "+"
+	multiLevelWildcard  = "#"
+)
+
+var paramNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// TopicPattern is a relative subscriber topic in which {name} segments are named
+// single-level wildcards.
+type TopicPattern struct {
+	segments []string
+	// params maps segment positions to parameter names.
+	params map[int]string
+}
+
+// ParseTopic parses a subscriber topic pattern.
+func ParseTopic(pattern string) (*TopicPattern, error) {
+	segments := strings.Split(pattern, "/")
+	topic := &TopicPattern{
+		segments: segments,
+		params:   make(map[int]string),
+	}
+
+	seen := make(map[string]bool)
+
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") && !strings.HasSuffix(segment, "}") {
+			continue
+		}
+
+		name := strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		if len(name)+2 != len(segment) || !paramNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("%w: invalid parameter segment %q", errs.ErrInvalidMQTTTopic, segment)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("%w: duplicate parameter %q", errs.ErrInvalidMQTTTopic, name)
+		}
+
+		seen[name] = true
+		topic.params[i] = name
+	}
+
+	return topic, nil
+}
+
+// Filter returns the MQTT topic filter of the pattern, with parameters replaced by +.
+func (p *TopicPattern) Filter() string {
+	segments := make([]string, len(p.segments))
+	for i, segment := range p.segments {
+		if _, ok := p.params[i]; ok {
+			segment = singleLevelWildcard
+		}
+
+		segments[i] = segment
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// Match matches a relative topic against the pattern and returns the named parameters.
+func (p *TopicPattern) Match(topic string) (map[string]string, bool) {
+	levels := strings.Split(topic, "/")
+	params := make(map[string]string, len(p.params))
+
+	for i, segment := range p.segments {
+		if segment == multiLevelWildcard {
+			return params, true
+		}
+
+		if i >= len(levels) {
+			return nil, false
+		}
+
+		if name, ok := p.params[i]; ok {
+			params[name] = levels[i]
+
+			continue
+		}
+
+		if segment != singleLevelWildcard && segment != levels[i] {
+			return nil, false
+		}
+	}
+
+	return params, len(levels) == len(p.segments)
+}
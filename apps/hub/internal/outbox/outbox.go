@@ -0,0 +1,383 @@
+// Package outbox provides the Postgres-backed notification outbox.
+// Messages sent through it are persisted and delivered by the outbox worker, which retries
+// every transport of the channel separately with exponential backoff.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/backoff"
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/database"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+	"github.com/abgeo/maroid/apps/hub/internal/repository"
+	"github.com/abgeo/maroid/libs/notifier"
+	"github.com/abgeo/maroid/libs/notifier/dispatcher"
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+// Outbox persists notifications and delivers them through the channel dispatcher transports.
+type Outbox struct {
+	cfg        config.NotifierOutbox
+	logger     *slog.Logger
+	db         *sqlx.DB
+	dispatcher *dispatcher.ChannelDispatcher
+}
+
+// Entry is an outbox message along with the deliveries through its transports.
+type Entry struct {
+	Message    model.NotificationMessage
+	Title      string
+	Deliveries []model.NotificationDelivery
+}
+
+var _ notifierapi.Dispatcher = (*Outbox)(nil)
+
+// New creates a new Outbox.
+func New(
+	cfg *config.Config,
+	logger *slog.Logger,
+	db *sqlx.DB,
+	channelDispatcher *dispatcher.ChannelDispatcher,
+) *Outbox {
+	return &Outbox{
+		cfg: cfg.NotifierOutbox,
+		logger: logger.With(
+			slog.String("component", "outbox"),
+		),
+		db:         db,
+		dispatcher: channelDispatcher,
+	}
+}
+
+// Send enqueues a message for the primary transports of the channel: every one of them,
+// or only the first with the first strategy. It returns once the message is persisted
+// with the enqueued transports reported as queued; delivery happens in the outbox worker.
+func (o *Outbox) Send(
+	ctx context.Context,
+	channelName string,
+	msg notifierapi.Message,
+) (notifierapi.DeliveryReport, error) {
+	report := notifierapi.DeliveryReport{Channel: channelName}
+
+	channel, exists := o.dispatcher.Channel(channelName)
+	if !exists {
+		return report, fmt.Errorf("%w: %q", dispatcher.ErrChannelNotFound, channelName)
+	}
+
+	encoded, err := json.Marshal(msg)
+	if err != nil {
+		return report, fmt.Errorf("encoding notification: %w", err)
+	}
+
+	transports := initialTransports(channel, channel.Transports)
+
+	var id string
+
+	err = database.WithTx(ctx, o.db, func(tx *sqlx.Tx) error {
+		var insertErr error
+
+		id, insertErr = repository.NewNotificationMessage(tx).Insert(ctx, &model.NotificationMessage{
+			Channel: channelName,
+			Message: encoded,
+		})
+		if insertErr != nil {
+			return insertErr
+		}
+
+		return repository.NewNotificationDelivery(tx).InsertMany(ctx, id, transports, false)
+	})
+	if err != nil {
+		return report, fmt.Errorf("enqueueing notification: %w", err)
+	}
+
+	o.logger.DebugContext(ctx, "notification enqueued",
+		slog.String("message_id", id),
+		slog.String("channel", channelName),
+		slog.Any("transports", transports),
+	)
+
+	for _, transport := range transports {
+		report.Deliveries = append(report.Deliveries, notifierapi.TransportDelivery{
+			Transport: transport,
+			Status:    notifierapi.DeliveryQueued,
+		})
+	}
+
+	return report, nil
+}
+
+// Channels returns a sorted slice of all configured channel names.
+func (o *Outbox) Channels() []string {
+	return o.dispatcher.Channels()
+}
+
+// ClaimDue marks up to limit due deliveries as running and returns them.
+func (o *Outbox) ClaimDue(ctx context.Context, limit int) ([]model.NotificationDelivery, error) {
+	var deliveries []model.NotificationDelivery
+
+	err := database.WithTx(ctx, o.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		deliveries, err = repository.NewNotificationDelivery(tx).ClaimDue(
+			ctx,
+			limit,
+			time.Now().Add(o.cfg.LeaseDuration),
+		)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claiming due notification deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// Deliver sends a claimed delivery through its transport and records the outcome.
+// The send is bounded by the configured delivery timeout.
+// Failed deliveries are retried with exponential backoff until they exhaust their attempts;
+// then the channel delivery strategy decides what to enqueue next (see enqueueNext).
+func (o *Outbox) Deliver(ctx context.Context, delivery *model.NotificationDelivery) {
+	logger := o.logger.With(
+		slog.String("message_id", delivery.MessageID),
+		slog.String("transport", delivery.Transport),
+		slog.Bool("fallback", delivery.Fallback),
+		slog.Int("attempt", delivery.Attempts),
+		slog.Int("max_attempts", o.cfg.MaxAttempts),
+	)
+
+	sendCtx, cancel := context.WithTimeout(ctx, o.cfg.DeliveryTimeout)
+	message, sendErr := o.send(sendCtx, delivery)
+
+	cancel()
+
+	err := database.WithTx(ctx, o.db, func(tx *sqlx.Tx) error {
+		repo := repository.NewNotificationDelivery(tx)
+
+		switch {
+		case sendErr == nil:
+			logger.InfoContext(ctx, "notification delivered")
+
+			return repo.MarkDelivered(ctx, delivery)
+		case delivery.Attempts >= o.cfg.MaxAttempts:
+			logger.ErrorContext(ctx, "notification delivery failed permanently", slog.Any("error", sendErr))
+
+			if err := repo.MarkFailed(ctx, delivery, sendErr.Error()); err != nil {
+				return err
+			}
+
+			return o.enqueueNext(ctx, tx, delivery, message)
+		default:
+			runAt := time.Now().Add(o.backoff(delivery))
+
+			logger.WarnContext(ctx, "notification delivery failed, retry scheduled",
+				slog.Time("retry_at", runAt),
+				slog.Any("error", sendErr),
+			)
+
+			return repo.Retry(ctx, delivery, runAt, sendErr.Error())
+		}
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "recording notification delivery outcome failed", slog.Any("error", err))
+	}
+}
+
+// List returns up to limit messages, oldest first, that have a delivery in the given status.
+// An empty status lists every message that is not delivered through all its transports yet.
+func (o *Outbox) List(
+	ctx context.Context,
+	status model.NotificationDeliveryStatus,
+	limit int,
+) ([]Entry, error) {
+	var (
+		messages   []model.NotificationMessage
+		deliveries []model.NotificationDelivery
+	)
+
+	err := database.WithTx(ctx, o.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		messages, err = repository.NewNotificationMessage(tx).ListUndelivered(ctx, status, limit)
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]string, 0, len(messages))
+		for _, message := range messages {
+			ids = append(ids, message.ID)
+		}
+
+		deliveries, err = repository.NewNotificationDelivery(tx).ListByMessages(ctx, ids)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing notifications: %w", err)
+	}
+
+	byMessage := make(map[string][]model.NotificationDelivery, len(messages))
+	for _, delivery := range deliveries {
+		byMessage[delivery.MessageID] = append(byMessage[delivery.MessageID], delivery)
+	}
+
+	entries := make([]Entry, 0, len(messages))
+
+	for _, message := range messages {
+		var decoded notifierapi.Message
+
+		// The title is informational only, so an undecodable message is still listed.
+		_ = json.Unmarshal(message.Message, &decoded)
+
+		entries = append(entries, Entry{
+			Message:    message,
+			Title:      decoded.Title,
+			Deliveries: byMessage[message.ID],
+		})
+	}
+
+	return entries, nil
+}
+
+// Resend returns the failed deliveries of a message to the outbox with a fresh attempt budget
+// and returns their count. It returns ErrNotificationNotResendable if none failed.
+func (o *Outbox) Resend(ctx context.Context, messageID string) (int64, error) {
+	var count int64
+
+	err := database.WithTx(ctx, o.db, func(tx *sqlx.Tx) error {
+		if _, err := repository.NewNotificationMessage(tx).Get(ctx, messageID); err != nil {
+			return err
+		}
+
+		var err error
+
+		count, err = repository.NewNotificationDelivery(tx).ResetFailed(ctx, messageID)
+
+		return err
+	})
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	if count == 0 {
+		return 0, fmt.Errorf("%w: %s", errs.ErrNotificationNotResendable, messageID)
+	}
+
+	o.logger.InfoContext(ctx, "notification re-sent",
+		slog.String("message_id", messageID),
+		slog.Int64("deliveries", count),
+	)
+
+	return count, nil
+}
+
+// send loads the message of a delivery and sends it through the delivery transport.
+// The message is returned for enqueueing fallbacks even if sending failed.
+func (o *Outbox) send(
+	ctx context.Context,
+	delivery *model.NotificationDelivery,
+) (*model.NotificationMessage, error) {
+	// A delivery reclaimed after a crashed attempt may already be out of attempts.
+	if delivery.Attempts > o.cfg.MaxAttempts {
+		return nil, errs.ErrNotificationAttemptsExhausted
+	}
+
+	var message *model.NotificationMessage
+
+	err := database.WithTx(ctx, o.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		message, err = repository.NewNotificationMessage(tx).Get(ctx, delivery.MessageID)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading notification: %w", err)
+	}
+
+	var msg notifierapi.Message
+	if err = json.Unmarshal(message.Message, &msg); err != nil {
+		return message, fmt.Errorf("decoding notification: %w", err)
+	}
+
+	return message, o.dispatcher.SendVia(ctx, delivery.Transport, msg) //nolint:wrapcheck
+}
+
+// enqueueNext enqueues what the channel delivery strategy sends after a delivery failed
+// permanently. With the first strategy, that is the next transport of the same group.
+// Otherwise the fallback transports are enqueued once the primaries cannot satisfy
+// the strategy: after any failure with the all strategy, after all of them failed otherwise.
+func (o *Outbox) enqueueNext(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	delivery *model.NotificationDelivery,
+	message *model.NotificationMessage,
+) error {
+	if message == nil {
+		return nil
+	}
+
+	channel, exists := o.dispatcher.Channel(message.Channel)
+	if !exists {
+		return nil
+	}
+
+	repo := repository.NewNotificationDelivery(tx)
+	strategy := channel.EffectiveStrategy()
+
+	if strategy == notifier.StrategyFirst {
+		group := channel.Transports
+		if delivery.Fallback {
+			group = channel.Fallback
+		}
+
+		index := slices.Index(group, delivery.Transport)
+		if index >= 0 && index+1 < len(group) {
+			return repo.InsertMany(ctx, delivery.MessageID, group[index+1:index+2], delivery.Fallback)
+		}
+	}
+
+	if delivery.Fallback || len(channel.Fallback) == 0 {
+		return nil
+	}
+
+	if strategy != notifier.StrategyAll {
+		remaining, err := repo.CountNotFailed(ctx, delivery.MessageID, false)
+		if err != nil || remaining > 0 {
+			return err
+		}
+	}
+
+	transports := initialTransports(channel, channel.Fallback)
+
+	o.logger.WarnContext(ctx, "primary transports failed, enqueueing fallback transports",
+		slog.String("message_id", delivery.MessageID),
+		slog.String("channel", message.Channel),
+		slog.Any("transports", transports),
+	)
+
+	return repo.InsertMany(ctx, delivery.MessageID, transports, true)
+}
+
+// initialTransports returns the transports of a group to enqueue first:
+// only the first one with the first strategy, all of them otherwise.
+func initialTransports(channel notifier.ChannelConfig, group []string) []string {
+	if channel.EffectiveStrategy() == notifier.StrategyFirst && len(group) > 0 {
+		return group[:1]
+	}
+
+	return group
+}
+
+func (o *Outbox) backoff(delivery *model.NotificationDelivery) time.Duration {
+	return backoff.Exponential(o.cfg.Backoff, o.cfg.MaxBackoff, delivery.Attempts-1)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/mymmrac/telego"
 
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
 	"github.com/abgeo/maroid/libs/notifierapi"
 	"github.com/abgeo/maroid/libs/pluginapi"
 	"github.com/abgeo/maroid/libs/pluginapi/telegram/conversation"
@@ -21,9 +22,16 @@ type Host struct {
 	notifier                   notifierapi.Dispatcher
 	telegramBot                *telego.Bot
 	telegramConversationEngine conversation.Engine
+	scheduler                  pluginapi.Scheduler
+	tasks                      pluginapi.TaskQueue
+	mqttPublisher              *mqttclient.Publisher
 }
 
-var _ pluginapi.Host = (*Host)(nil)
+// ForPlugin returns a host scoped to a single plugin. It is passed to the plugin constructor
+// and bound to the plugin ID once the plugin is constructed.
+func (h *Host) ForPlugin() *PluginHost {
+	return &PluginHost{Host: h}
+}
 
 // New creates and returns a new Host instance using the given dependency container.
 func New(
@@ -32,6 +40,9 @@ func New(
 	notifier notifierapi.Dispatcher,
 	telegramBot *telego.Bot,
 	telegramConversationEngine conversation.Engine,
+	scheduler pluginapi.Scheduler,
+	tasks pluginapi.TaskQueue,
+	mqttPublisher *mqttclient.Publisher,
 ) (*Host, error) {
 	return &Host{
 		logger:                     logger,
@@ -39,6 +50,9 @@ func New(
 		notifier:                   notifier,
 		telegramBot:                telegramBot,
 		telegramConversationEngine: telegramConversationEngine,
+		scheduler:                  scheduler,
+		tasks:                      tasks,
+		mqttPublisher:              mqttPublisher,
 	}, nil
 }
 
@@ -53,13 +67,15 @@ func (h *Host) Database() (*sqlx.DB, error) {
 }
 
 // Notifier returns the notifier dispatcher instance from the dependency container.
+// Messages sent with a dry-run context are recorded instead of being delivered.
 func (h *Host) Notifier() (notifierapi.Dispatcher, error) {
-	return h.notifier, nil
+	return &dryRunDispatcher{logger: h.logger, notifier: h.notifier}, nil
 }
 
 // TelegramBot returns the wrapped Telegram bot instance from the dependency container.
+// Calls made with a dry-run context are logged instead of reaching Telegram.
 func (h *Host) TelegramBot() (pluginapi.TelegramBot, error) {
-	return &telegramBotWrapper{bot: h.telegramBot}, nil
+	return &telegramBotWrapper{logger: h.logger, bot: h.telegramBot}, nil
 }
 
 // TelegramConversationEngine returns the Telegram conversation engine instance from the dependency container.
@@ -68,3 +84,13 @@ func (h *Host) TelegramBot() (pluginapi.TelegramBot, error) {
 func (h *Host) TelegramConversationEngine() conversation.Engine {
 	return h.telegramConversationEngine
 }
+
+// Scheduler returns the runtime task scheduler instance from the dependency container.
+func (h *Host) Scheduler() (pluginapi.Scheduler, error) {
+	return h.scheduler, nil
+}
+
+// Tasks returns the task queue instance from the dependency container.
+func (h *Host) Tasks() (pluginapi.TaskQueue, error) {
+	return h.tasks, nil
+}
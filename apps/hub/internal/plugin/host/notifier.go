@@ -0,0 +1,43 @@
+package host
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/abgeo/maroid/libs/notifierapi"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// dryRunDispatcher records messages instead of sending them when the context
+// carries the dry-run mode and delegates to the wrapped dispatcher otherwise.
+type dryRunDispatcher struct {
+	logger   *slog.Logger
+	notifier notifierapi.Dispatcher
+}
+
+var _ notifierapi.Dispatcher = (*dryRunDispatcher)(nil)
+
+func (d *dryRunDispatcher) Send(
+	ctx context.Context,
+	channelName string,
+	msg notifierapi.Message,
+) (notifierapi.DeliveryReport, error) {
+	dryRun, ok := pluginapi.DryRunFromContext(ctx)
+	if !ok {
+		return d.notifier.Send(ctx, channelName, msg) //nolint:wrapcheck
+	}
+
+	dryRun.RecordNotification(channelName, msg)
+
+	d.logger.InfoContext(ctx, "dry run: notification recorded instead of sent",
+		slog.String("channel", channelName),
+		slog.String("title", msg.Title),
+		slog.Int("attachments", len(msg.Attachments)),
+	)
+
+	return notifierapi.DeliveryReport{Channel: channelName}, nil
+}
+
+func (d *dryRunDispatcher) Channels() []string {
+	return d.notifier.Channels()
+}
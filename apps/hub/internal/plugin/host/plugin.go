@@ -0,0 +1,56 @@
+package host
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// PluginHost is a Host scoped to a single plugin. Capabilities confined to the plugin
+// namespace, such as MQTT publishing, become usable once the host is bound to the plugin ID.
+type PluginHost struct {
+	*Host
+
+	pluginID atomic.Pointer[pluginapi.PluginID]
+}
+
+var _ pluginapi.Host = (*PluginHost)(nil)
+
+// Bind binds the host to the plugin with the given ID.
+func (h *PluginHost) Bind(pluginID *pluginapi.PluginID) {
+	h.pluginID.Store(pluginID)
+}
+
+// MQTT returns an MQTT publisher confined to the plugin namespace.
+func (h *PluginHost) MQTT() (pluginapi.MQTTPublisher, error) {
+	return &mqttPublisher{host: h}, nil
+}
+
+type mqttPublisher struct {
+	host *PluginHost
+}
+
+func (p *mqttPublisher) Publish(
+	ctx context.Context,
+	relativeTopic string,
+	payload []byte,
+	qos byte,
+	retained bool,
+) error {
+	pluginID := p.host.pluginID.Load()
+	if pluginID == nil {
+		return errs.ErrPluginHostNotBound
+	}
+
+	if err := mqttclient.ValidateRelativeTopic(relativeTopic, false); err != nil {
+		return err
+	}
+
+	topic := fmt.Sprintf("%s/%s", mqttclient.Namespace(pluginID), relativeTopic)
+
+	return p.host.mqttPublisher.Publish(ctx, topic, payload, qos, retained) //nolint:wrapcheck
+}
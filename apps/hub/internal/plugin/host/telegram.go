@@ -2,18 +2,34 @@ package host
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/mymmrac/telego"
+
+	"github.com/abgeo/maroid/libs/pluginapi"
 )
 
 type telegramBotWrapper struct {
-	bot *telego.Bot
+	logger *slog.Logger
+	bot    *telego.Bot
 }
 
 func (t *telegramBotWrapper) SendMessage(
 	ctx context.Context,
 	params *telego.SendMessageParams,
 ) (*telego.Message, error) {
+	if pluginapi.IsDryRun(ctx) {
+		t.logger.InfoContext(ctx, "dry run: telegram message not sent",
+			slog.String("chat_id", params.ChatID.String()),
+			slog.String("text", params.Text),
+		)
+
+		return &telego.Message{
+			Chat: telego.Chat{ID: params.ChatID.ID, Username: params.ChatID.Username},
+			Text: params.Text,
+		}, nil
+	}
+
 	return t.bot.SendMessage(ctx, params) //nolint:wrapcheck
 }
 
@@ -21,6 +37,15 @@ func (t *telegramBotWrapper) AnswerCallbackQuery(
 	ctx context.Context,
 	params *telego.AnswerCallbackQueryParams,
 ) error {
+	if pluginapi.IsDryRun(ctx) {
+		t.logger.InfoContext(ctx, "dry run: telegram callback query not answered",
+			slog.String("callback_query_id", params.CallbackQueryID),
+			slog.String("text", params.Text),
+		)
+
+		return nil
+	}
+
 	return t.bot.AnswerCallbackQuery(ctx, params) //nolint:wrapcheck
 }
 
@@ -28,5 +53,19 @@ func (t *telegramBotWrapper) EditMessageText(
 	ctx context.Context,
 	params *telego.EditMessageTextParams,
 ) (*telego.Message, error) {
+	if pluginapi.IsDryRun(ctx) {
+		t.logger.InfoContext(ctx, "dry run: telegram message not edited",
+			slog.String("chat_id", params.ChatID.String()),
+			slog.Int("message_id", params.MessageID),
+			slog.String("text", params.Text),
+		)
+
+		return &telego.Message{
+			MessageID: params.MessageID,
+			Chat:      telego.Chat{ID: params.ChatID.ID, Username: params.ChatID.Username},
+			Text:      params.Text,
+		}, nil
+	}
+
 	return t.bot.EditMessageText(ctx, params) //nolint:wrapcheck
 }
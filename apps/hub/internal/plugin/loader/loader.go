@@ -9,6 +9,7 @@ import (
 	"github.com/abgeo/maroid/apps/hub/internal/config"
 	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
 	"github.com/abgeo/maroid/apps/hub/internal/handler"
+	pluginhost "github.com/abgeo/maroid/apps/hub/internal/plugin/host"
 	"github.com/abgeo/maroid/apps/hub/internal/plugin/registrar"
 	"github.com/abgeo/maroid/apps/hub/internal/registry"
 	"github.com/abgeo/maroid/libs/pluginapi"
@@ -20,25 +21,29 @@ const ConstructorSymbol = "New"
 
 // Loader is responsible for loading and registering plugins.
 type Loader struct {
-	host pluginapi.Host
+	host *pluginhost.Host
 
 	registrars []registrar.Registrar
 }
 
 // New creates a new Loader.
 func New(
-	host pluginapi.Host,
+	host *pluginhost.Host,
 	cfg *config.Config,
 	jwtSvc *auth.JWTService,
 	commandRegistry *registry.CommandRegistry,
 	cronRegistry *registry.CronRegistry,
 	handlerRegistry *handler.Registry,
+	homeAssistantEntityRegistry *registry.HomeAssistantEntityRegistry,
 	migrationRegistry *registry.MigrationRegistry,
 	mqttSubscriberRegistry *registry.MQTTSubscriberRegistry,
 	pluginRegistry *registry.PluginRegistry,
+	scheduledTaskRegistry *registry.ScheduledTaskRegistry,
+	taskHandlerRegistry *registry.TaskHandlerRegistry,
 	telegramCommandRegistry *registry.TelegramCommandRegistry,
 	telegramConversationRegistry *registry.TelegramConversationRegistry,
 	uiRegistry *registry.UIRegistry,
+	workerRegistry *registry.WorkerRegistry,
 ) *Loader {
 	logger := host.Logger()
 
@@ -50,11 +55,15 @@ func New(
 			registrar.NewCommandRegistrar(commandRegistry),
 			registrar.NewCronRegistrar(cronRegistry),
 			registrar.NewHandlerRegistrar(logger, cfg, jwtSvc, handlerRegistry),
+			registrar.NewHomeAssistantEntityRegistrar(homeAssistantEntityRegistry),
 			registrar.NewMigrationRegistrar(migrationRegistry),
 			registrar.NewMQTTSubscriberRegistrar(mqttSubscriberRegistry),
+			registrar.NewScheduledTaskRegistrar(scheduledTaskRegistry),
+			registrar.NewTaskHandlerRegistrar(taskHandlerRegistry),
 			registrar.NewTelegramCommandRegistrar(telegramCommandRegistry),
 			registrar.NewTelegramConversationRegistrar(telegramConversationRegistry),
 			registrar.NewUIRegistrar(uiRegistry),
+			registrar.NewWorkerRegistrar(workerRegistry),
 		},
 	}
 }
@@ -68,7 +77,9 @@ func (r *Loader) Load(path string, cfg map[string]any) error {
 		return err
 	}
 
-	plg, err := constructor(r.host, cfg)
+	pluginHost := r.host.ForPlugin()
+
+	plg, err := constructor(pluginHost, cfg)
 	if err != nil {
 		return err
 	}
@@ -77,6 +88,8 @@ func (r *Loader) Load(path string, cfg map[string]any) error {
 		return err
 	}
 
+	pluginHost.Bind(plg.Meta().ID)
+
 	if err = r.registerCapabilities(plg); err != nil {
 		return err
 	}
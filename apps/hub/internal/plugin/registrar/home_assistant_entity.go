@@ -0,0 +1,63 @@
+package registrar
+
+import (
+	"fmt"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// HomeAssistantEntityRegistrar is responsible for registering plugin Home Assistant entities.
+type HomeAssistantEntityRegistrar struct {
+	registry *registry.HomeAssistantEntityRegistry
+}
+
+var _ Registrar = (*HomeAssistantEntityRegistrar)(nil)
+
+// NewHomeAssistantEntityRegistrar creates a new HomeAssistantEntityRegistrar.
+func NewHomeAssistantEntityRegistrar(
+	reg *registry.HomeAssistantEntityRegistry,
+) *HomeAssistantEntityRegistrar {
+	return &HomeAssistantEntityRegistrar{
+		registry: reg,
+	}
+}
+
+// Name returns the name of the registrar.
+func (r *HomeAssistantEntityRegistrar) Name() string {
+	return "home_assistant_entity"
+}
+
+// Supports indicates whether the registrar can handle the given plugin.
+func (r *HomeAssistantEntityRegistrar) Supports(plugin pluginapi.Plugin) bool {
+	_, ok := plugin.(pluginapi.HomeAssistantEntityPlugin)
+
+	return ok
+}
+
+// Register handles the registration of a plugin capability.
+func (r *HomeAssistantEntityRegistrar) Register(plugin pluginapi.Plugin) error {
+	id := plugin.Meta().ID
+
+	entityPlugin, ok := plugin.(pluginapi.HomeAssistantEntityPlugin)
+	if !ok {
+		return fmt.Errorf(
+			"plugin %s does not support HomeAssistantEntity capability: %w",
+			id,
+			errs.ErrPluginCapabilityNotSupported,
+		)
+	}
+
+	entities, err := entityPlugin.HomeAssistantEntities()
+	if err != nil {
+		return fmt.Errorf("retrieving Home Assistant entities for plugin %s: %w", id, err)
+	}
+
+	err = r.registry.Register(plugin.Meta(), entities...)
+	if err != nil {
+		return fmt.Errorf("registering Home Assistant entities for plugin %s: %w", id, err)
+	}
+
+	return nil
+}
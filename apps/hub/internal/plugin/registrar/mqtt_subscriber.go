@@ -2,9 +2,10 @@ package registrar
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttsubscriber"
 	"github.com/abgeo/maroid/apps/hub/internal/registry"
 	"github.com/abgeo/maroid/libs/pluginapi"
 )
@@ -46,7 +47,7 @@ func (r *MQTTSubscriberRegistrar) Register(plugin pluginapi.Plugin) error {
 		)
 	}
 
-	namespace := strings.ReplaceAll(id.String(), ".", "/")
+	namespace := mqttclient.Namespace(id)
 
 	subscribers, err := mqttPlugin.MQTTSubscribers()
 	if err != nil {
@@ -56,11 +57,12 @@ func (r *MQTTSubscriberRegistrar) Register(plugin pluginapi.Plugin) error {
 	for _, sub := range subscribers {
 		meta := sub.Meta()
 
-		if err = validateMQTTTopic(meta.Topic); err != nil {
-			return fmt.Errorf("invalid topic for subscriber %s in plugin %s: %w", meta.ID, id, err)
+		filter, err := subscriberFilter(meta)
+		if err != nil {
+			return fmt.Errorf("invalid subscriber %s in plugin %s: %w", meta.ID, id, err)
 		}
 
-		effectiveTopic := namespace + "/" + meta.Topic
+		effectiveTopic := namespace + "/" + filter
 
 		if err = r.registry.Register(effectiveTopic, sub); err != nil {
 			return fmt.Errorf(
@@ -75,21 +77,22 @@ func (r *MQTTSubscriberRegistrar) Register(plugin pluginapi.Plugin) error {
 	return nil
 }
 
-func validateMQTTTopic(topic string) error {
-	if topic == "" {
-		return fmt.Errorf("%w: topic must not be empty", errs.ErrInvalidMQTTTopic)
+// subscriberFilter validates the subscriber topic pattern and codec and returns the topic filter,
+// with named parameters replaced by single-level wildcards.
+func subscriberFilter(meta pluginapi.MQTTSubscriberMeta) (string, error) {
+	pattern, err := mqttsubscriber.ParseTopic(meta.Topic)
+	if err != nil {
+		return "", fmt.Errorf("parsing topic: %w", err)
 	}
 
-	if strings.HasPrefix(topic, "/") {
-		return fmt.Errorf("%w: topic must not start with /", errs.ErrInvalidMQTTTopic)
+	filter := pattern.Filter()
+	if err = mqttclient.ValidateRelativeTopic(filter, true); err != nil {
+		return "", fmt.Errorf("validating topic: %w", err)
 	}
 
-	if strings.HasPrefix(topic, "$") {
-		return fmt.Errorf(
-			"%w: topic must not start with $ (reserved for broker internals)",
-			errs.ErrInvalidMQTTTopic,
-		)
+	if err = mqttsubscriber.ValidateCodec(meta.Codec); err != nil {
+		return "", fmt.Errorf("validating codec: %w", err)
 	}
 
-	return nil
+	return filter, nil
 }
@@ -0,0 +1,61 @@
+package registrar
+
+import (
+	"fmt"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// ScheduledTaskRegistrar is responsible for registering plugin scheduled task handlers.
+type ScheduledTaskRegistrar struct {
+	registry *registry.ScheduledTaskRegistry
+}
+
+var _ Registrar = (*ScheduledTaskRegistrar)(nil)
+
+// NewScheduledTaskRegistrar creates a new ScheduledTaskRegistrar.
+func NewScheduledTaskRegistrar(reg *registry.ScheduledTaskRegistry) *ScheduledTaskRegistrar {
+	return &ScheduledTaskRegistrar{
+		registry: reg,
+	}
+}
+
+// Name returns the name of the registrar.
+func (r *ScheduledTaskRegistrar) Name() string {
+	return "scheduled_task"
+}
+
+// Supports indicates whether the registrar can handle the given plugin.
+func (r *ScheduledTaskRegistrar) Supports(plugin pluginapi.Plugin) bool {
+	_, ok := plugin.(pluginapi.ScheduledTaskPlugin)
+
+	return ok
+}
+
+// Register handles the registration of a plugin capability.
+func (r *ScheduledTaskRegistrar) Register(plugin pluginapi.Plugin) error {
+	id := plugin.Meta().ID
+
+	scheduledTaskPlugin, ok := plugin.(pluginapi.ScheduledTaskPlugin)
+	if !ok {
+		return fmt.Errorf(
+			"plugin %s does not support ScheduledTask capability: %w",
+			id,
+			errs.ErrPluginCapabilityNotSupported,
+		)
+	}
+
+	handlers, err := scheduledTaskPlugin.ScheduledTaskHandlers()
+	if err != nil {
+		return fmt.Errorf("retrieving scheduled task handlers for plugin %s: %w", id, err)
+	}
+
+	err = r.registry.Register(handlers...)
+	if err != nil {
+		return fmt.Errorf("registering scheduled task handlers for plugin %s: %w", id, err)
+	}
+
+	return nil
+}
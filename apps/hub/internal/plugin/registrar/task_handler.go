@@ -0,0 +1,61 @@
+package registrar
+
+import (
+	"fmt"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// TaskHandlerRegistrar is responsible for registering plugin task handlers.
+type TaskHandlerRegistrar struct {
+	registry *registry.TaskHandlerRegistry
+}
+
+var _ Registrar = (*TaskHandlerRegistrar)(nil)
+
+// NewTaskHandlerRegistrar creates a new TaskHandlerRegistrar.
+func NewTaskHandlerRegistrar(reg *registry.TaskHandlerRegistry) *TaskHandlerRegistrar {
+	return &TaskHandlerRegistrar{
+		registry: reg,
+	}
+}
+
+// Name returns the name of the registrar.
+func (r *TaskHandlerRegistrar) Name() string {
+	return "task_handler"
+}
+
+// Supports indicates whether the registrar can handle the given plugin.
+func (r *TaskHandlerRegistrar) Supports(plugin pluginapi.Plugin) bool {
+	_, ok := plugin.(pluginapi.TaskHandlerPlugin)
+
+	return ok
+}
+
+// Register handles the registration of a plugin capability.
+func (r *TaskHandlerRegistrar) Register(plugin pluginapi.Plugin) error {
+	id := plugin.Meta().ID
+
+	taskHandlerPlugin, ok := plugin.(pluginapi.TaskHandlerPlugin)
+	if !ok {
+		return fmt.Errorf(
+			"plugin %s does not support TaskHandler capability: %w",
+			id,
+			errs.ErrPluginCapabilityNotSupported,
+		)
+	}
+
+	handlers, err := taskHandlerPlugin.TaskHandlers()
+	if err != nil {
+		return fmt.Errorf("retrieving task handlers for plugin %s: %w", id, err)
+	}
+
+	err = r.registry.Register(handlers...)
+	if err != nil {
+		return fmt.Errorf("registering task handlers for plugin %s: %w", id, err)
+	}
+
+	return nil
+}
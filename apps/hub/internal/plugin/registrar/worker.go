@@ -0,0 +1,61 @@
+package registrar
+
+import (
+	"fmt"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// WorkerRegistrar is responsible for registering plugin workers.
+type WorkerRegistrar struct {
+	registry *registry.WorkerRegistry
+}
+
+var _ Registrar = (*WorkerRegistrar)(nil)
+
+// NewWorkerRegistrar creates a new WorkerRegistrar.
+func NewWorkerRegistrar(reg *registry.WorkerRegistry) *WorkerRegistrar {
+	return &WorkerRegistrar{
+		registry: reg,
+	}
+}
+
+// Name returns the name of the registrar.
+func (r *WorkerRegistrar) Name() string {
+	return "worker"
+}
+
+// Supports indicates whether the registrar can handle the given plugin.
+func (r *WorkerRegistrar) Supports(plugin pluginapi.Plugin) bool {
+	_, ok := plugin.(pluginapi.WorkerPlugin)
+
+	return ok
+}
+
+// Register handles the registration of a plugin capability.
+func (r *WorkerRegistrar) Register(plugin pluginapi.Plugin) error {
+	id := plugin.Meta().ID
+
+	workerPlugin, ok := plugin.(pluginapi.WorkerPlugin)
+	if !ok {
+		return fmt.Errorf(
+			"plugin %s does not support Worker capability: %w",
+			id,
+			errs.ErrPluginCapabilityNotSupported,
+		)
+	}
+
+	workers, err := workerPlugin.Workers()
+	if err != nil {
+		return fmt.Errorf("retrieving workers for plugin %s: %w", id, err)
+	}
+
+	err = r.registry.Register(id, workers...)
+	if err != nil {
+		return fmt.Errorf("registering workers for plugin %s: %w", id, err)
+	}
+
+	return nil
+}
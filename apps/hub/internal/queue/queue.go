@@ -0,0 +1,194 @@
+// Package queue provides the Postgres-backed task queue exposed to plugins through Host.Tasks().
+// Tasks are processed by the queue worker and retried with exponential backoff on failure.
+package queue
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/backoff"
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/database"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/apps/hub/internal/repository"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// Queue persists tasks enqueued by plugins and runs them with their handlers.
+type Queue struct {
+	cfg      config.Queue
+	logger   *slog.Logger
+	db       *sqlx.DB
+	registry *registry.TaskHandlerRegistry
+}
+
+var _ pluginapi.TaskQueue = (*Queue)(nil)
+
+// New creates a new Queue.
+func New(
+	cfg *config.Config,
+	logger *slog.Logger,
+	db *sqlx.DB,
+	taskHandlerRegistry *registry.TaskHandlerRegistry,
+) *Queue {
+	return &Queue{
+		cfg: cfg.Queue,
+		logger: logger.With(
+			slog.String("component", "queue"),
+		),
+		db:       db,
+		registry: taskHandlerRegistry,
+	}
+}
+
+// Enqueue persists a task of the given type and returns its ID.
+// It returns an error if no handler is registered for the task type.
+func (q *Queue) Enqueue(
+	ctx context.Context,
+	taskType string,
+	payload []byte,
+	opts pluginapi.TaskOptions,
+) (string, error) {
+	if _, ok := q.registry.Get(taskType); !ok {
+		return "", fmt.Errorf("%w: %s", errs.ErrTaskHandlerNotFound, taskType)
+	}
+
+	entity := &model.QueuedTask{
+		Type:        taskType,
+		Payload:     payload,
+		MaxAttempts: opts.MaxAttempts,
+		Backoff:     opts.Backoff,
+		RunAt:       opts.RunAt,
+	}
+
+	if entity.MaxAttempts <= 0 {
+		entity.MaxAttempts = q.cfg.DefaultMaxAttempts
+	}
+
+	if entity.Backoff <= 0 {
+		entity.Backoff = q.cfg.DefaultBackoff
+	}
+
+	if entity.RunAt.IsZero() {
+		entity.RunAt = time.Now()
+	}
+
+	if opts.UniqueKey != "" {
+		entity.UniqueKey = &opts.UniqueKey
+	}
+
+	var id string
+
+	err := database.WithTx(ctx, q.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		id, err = repository.NewQueuedTask(tx).Insert(ctx, entity)
+
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("enqueueing task: %w", err)
+	}
+
+	q.logger.DebugContext(ctx, "task enqueued",
+		slog.String("task_id", id),
+		slog.String("type", taskType),
+		slog.String("unique_key", opts.UniqueKey),
+		slog.Time("run_at", entity.RunAt),
+		slog.Bool("dry_run", pluginapi.IsDryRun(ctx)),
+	)
+
+	return id, nil
+}
+
+// ClaimDue marks up to limit due tasks as running and returns them.
+func (q *Queue) ClaimDue(ctx context.Context, limit int) ([]model.QueuedTask, error) {
+	var tasks []model.QueuedTask
+
+	err := database.WithTx(ctx, q.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		tasks, err = repository.NewQueuedTask(tx).ClaimDue(
+			ctx,
+			limit,
+			time.Now().Add(q.cfg.LeaseDuration),
+		)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claiming due tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// Run executes a claimed task with its handler and records the outcome.
+// Failed tasks are retried with exponential backoff until they exhaust their attempts.
+func (q *Queue) Run(ctx context.Context, task *model.QueuedTask) {
+	logger := q.logger.With(
+		slog.String("task_id", task.ID),
+		slog.String("type", task.Type),
+		slog.Int("attempt", task.Attempts),
+		slog.Int("max_attempts", task.MaxAttempts),
+	)
+
+	runErr := q.execute(ctx, task)
+
+	err := database.WithTx(ctx, q.db, func(tx *sqlx.Tx) error {
+		repo := repository.NewQueuedTask(tx)
+
+		switch {
+		case runErr == nil:
+			logger.InfoContext(ctx, "task completed successfully")
+
+			return repo.MarkSucceeded(ctx, task)
+		case task.Attempts >= task.MaxAttempts:
+			logger.ErrorContext(ctx, "task failed permanently", slog.Any("error", runErr))
+
+			return repo.MarkFailed(ctx, task, runErr.Error())
+		default:
+			runAt := time.Now().Add(q.backoff(task))
+
+			logger.WarnContext(ctx, "task failed, retry scheduled",
+				slog.Time("retry_at", runAt),
+				slog.Any("error", runErr),
+			)
+
+			return repo.Retry(ctx, task, runAt, runErr.Error())
+		}
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "recording task outcome failed", slog.Any("error", err))
+	}
+}
+
+func (q *Queue) execute(ctx context.Context, task *model.QueuedTask) error {
+	// A task reclaimed after a crashed attempt may already be out of attempts.
+	if task.Attempts > task.MaxAttempts {
+		return errs.ErrTaskAttemptsExhausted
+	}
+
+	handler, ok := q.registry.Get(task.Type)
+	if !ok {
+		return fmt.Errorf("%w: %s", errs.ErrTaskHandlerNotFound, task.Type)
+	}
+
+	return handler.Handle(ctx, pluginapi.Task{ //nolint:wrapcheck
+		ID:          task.ID,
+		Type:        task.Type,
+		Payload:     task.Payload,
+		Attempt:     task.Attempts,
+		MaxAttempts: task.MaxAttempts,
+	})
+}
+
+func (q *Queue) backoff(task *model.QueuedTask) time.Duration {
+	return backoff.Exponential(task.Backoff, q.cfg.MaxBackoff, task.Attempts-1)
+}
@@ -36,6 +36,15 @@ func (r *CronRegistry) Register(jobs ...pluginapi.CronJob) error {
 	return nil
 }
 
+// Get returns a cron job by its ID.
+//
+//nolint:ireturn
+func (r *CronRegistry) Get(id string) (pluginapi.CronJob, bool) {
+	job, ok := r.jobs[id]
+
+	return job, ok
+}
+
 // All returns all registered cron jobs.
 func (r *CronRegistry) All() []pluginapi.CronJob {
 	return slices.Collect(maps.Values(r.jobs))
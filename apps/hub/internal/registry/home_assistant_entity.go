@@ -0,0 +1,99 @@
+package registry
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+var homeAssistantEntityIDPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// HomeAssistantEntityEntry represents a registered Home Assistant entity of a plugin.
+type HomeAssistantEntityEntry struct {
+	// Key is the entity key in the "<plugin id>:<entity id>" form.
+	Key    string
+	Plugin pluginapi.Metadata
+	Entity pluginapi.HomeAssistantEntity
+}
+
+// HomeAssistantEntityRegistry is a registry for plugin Home Assistant entities.
+type HomeAssistantEntityRegistry struct {
+	entries map[string]HomeAssistantEntityEntry
+}
+
+// NewHomeAssistantEntityRegistry creates a new HomeAssistantEntityRegistry.
+func NewHomeAssistantEntityRegistry() *HomeAssistantEntityRegistry {
+	return &HomeAssistantEntityRegistry{
+		entries: make(map[string]HomeAssistantEntityEntry),
+	}
+}
+
+// Register registers one or more entities of a plugin.
+// Every entity must implement the interface its component requires.
+func (r *HomeAssistantEntityRegistry) Register(
+	plugin pluginapi.Metadata,
+	entities ...pluginapi.HomeAssistantEntity,
+) error {
+	for _, entity := range entities {
+		meta := entity.Meta()
+
+		if err := validateHomeAssistantEntity(entity); err != nil {
+			return fmt.Errorf("%w: %s: %w", errs.ErrInvalidHomeAssistantEntity, meta.ID, err)
+		}
+
+		key := fmt.Sprintf("%s:%s", plugin.ID, meta.ID)
+
+		if _, exists := r.entries[key]; exists {
+			return fmt.Errorf("%w: %s", errs.ErrHomeAssistantEntityAlreadyRegistered, key)
+		}
+
+		r.entries[key] = HomeAssistantEntityEntry{
+			Key:    key,
+			Plugin: plugin,
+			Entity: entity,
+		}
+	}
+
+	return nil
+}
+
+// All returns all registered entities sorted by key.
+func (r *HomeAssistantEntityRegistry) All() []HomeAssistantEntityEntry {
+	entries := slices.Collect(maps.Values(r.entries))
+
+	slices.SortFunc(entries, func(a, b HomeAssistantEntityEntry) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return entries
+}
+
+func validateHomeAssistantEntity(entity pluginapi.HomeAssistantEntity) error {
+	meta := entity.Meta()
+
+	if !homeAssistantEntityIDPattern.MatchString(meta.ID) {
+		return fmt.Errorf("ID must match %s", homeAssistantEntityIDPattern)
+	}
+
+	var ok bool
+
+	switch meta.Component {
+	case pluginapi.HomeAssistantSensor, pluginapi.HomeAssistantBinarySensor:
+		_, ok = entity.(pluginapi.HomeAssistantStateEntity)
+	case pluginapi.HomeAssistantButton:
+		_, ok = entity.(pluginapi.HomeAssistantButtonEntity)
+	default:
+		return fmt.Errorf("unsupported component %q", meta.Component)
+	}
+
+	if !ok {
+		return fmt.Errorf("%s entity does not implement the %s interface", meta.Component, meta.Component)
+	}
+
+	return nil
+}
@@ -44,3 +44,12 @@ func (r *MQTTSubscriberRegistry) All() map[string]pluginapi.MQTTSubscriber {
 
 	return out
 }
+
+// Get retrieves the subscriber registered under the given effective topic.
+//
+//nolint:ireturn
+func (r *MQTTSubscriberRegistry) Get(effectiveTopic string) (pluginapi.MQTTSubscriber, bool) {
+	sub, ok := r.subscribers[effectiveTopic]
+
+	return sub, ok
+}
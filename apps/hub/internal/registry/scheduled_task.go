@@ -0,0 +1,51 @@
+package registry
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// ScheduledTaskRegistry is a registry for scheduled task handlers.
+type ScheduledTaskRegistry struct {
+	handlers map[string]pluginapi.ScheduledTaskHandler
+}
+
+// NewScheduledTaskRegistry creates a new ScheduledTaskRegistry.
+func NewScheduledTaskRegistry() *ScheduledTaskRegistry {
+	return &ScheduledTaskRegistry{
+		handlers: make(map[string]pluginapi.ScheduledTaskHandler),
+	}
+}
+
+// Register registers one or more scheduled task handlers.
+func (r *ScheduledTaskRegistry) Register(handlers ...pluginapi.ScheduledTaskHandler) error {
+	for _, handler := range handlers {
+		id := handler.Meta().ID
+
+		if _, exists := r.handlers[id]; exists {
+			return fmt.Errorf("%w: %s", errs.ErrScheduledTaskHandlerAlreadyRegistered, id)
+		}
+
+		r.handlers[id] = handler
+	}
+
+	return nil
+}
+
+// Get returns a scheduled task handler by its ID.
+//
+//nolint:ireturn
+func (r *ScheduledTaskRegistry) Get(id string) (pluginapi.ScheduledTaskHandler, bool) {
+	handler, ok := r.handlers[id]
+
+	return handler, ok
+}
+
+// All returns all registered scheduled task handlers.
+func (r *ScheduledTaskRegistry) All() []pluginapi.ScheduledTaskHandler {
+	return slices.Collect(maps.Values(r.handlers))
+}
@@ -0,0 +1,51 @@
+package registry
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// TaskHandlerRegistry is a registry for task handlers.
+type TaskHandlerRegistry struct {
+	handlers map[string]pluginapi.TaskHandler
+}
+
+// NewTaskHandlerRegistry creates a new TaskHandlerRegistry.
+func NewTaskHandlerRegistry() *TaskHandlerRegistry {
+	return &TaskHandlerRegistry{
+		handlers: make(map[string]pluginapi.TaskHandler),
+	}
+}
+
+// Register registers one or more task handlers.
+func (r *TaskHandlerRegistry) Register(handlers ...pluginapi.TaskHandler) error {
+	for _, handler := range handlers {
+		taskType := handler.Meta().Type
+
+		if _, exists := r.handlers[taskType]; exists {
+			return fmt.Errorf("%w: %s", errs.ErrTaskHandlerAlreadyRegistered, taskType)
+		}
+
+		r.handlers[taskType] = handler
+	}
+
+	return nil
+}
+
+// Get returns a task handler by its task type.
+//
+//nolint:ireturn
+func (r *TaskHandlerRegistry) Get(taskType string) (pluginapi.TaskHandler, bool) {
+	handler, ok := r.handlers[taskType]
+
+	return handler, ok
+}
+
+// All returns all registered task handlers.
+func (r *TaskHandlerRegistry) All() []pluginapi.TaskHandler {
+	return slices.Collect(maps.Values(r.handlers))
+}
@@ -0,0 +1,66 @@
+package registry
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// WorkerEntry represents a registered plugin worker entry.
+type WorkerEntry struct {
+	// Name is the fully qualified worker name in the "plugin:<plugin id>:<worker name>" form.
+	Name     string
+	PluginID *pluginapi.PluginID
+	Worker   pluginapi.Worker
+}
+
+// WorkerRegistry is a registry for plugin workers.
+type WorkerRegistry struct {
+	entries map[string]WorkerEntry
+}
+
+// NewWorkerRegistry creates a new WorkerRegistry.
+func NewWorkerRegistry() *WorkerRegistry {
+	return &WorkerRegistry{
+		entries: make(map[string]WorkerEntry),
+	}
+}
+
+// Register registers one or more workers of a plugin.
+func (r *WorkerRegistry) Register(pluginID *pluginapi.PluginID, workers ...pluginapi.Worker) error {
+	for _, wrk := range workers {
+		workerName := wrk.Name()
+		if workerName == "" || strings.Contains(workerName, ":") {
+			return fmt.Errorf("%w: %q", errs.ErrInvalidWorkerName, workerName)
+		}
+
+		name := fmt.Sprintf("plugin:%s:%s", pluginID, workerName)
+
+		if _, exists := r.entries[name]; exists {
+			return fmt.Errorf("%w: %s", errs.ErrWorkerAlreadyRegistered, name)
+		}
+
+		r.entries[name] = WorkerEntry{
+			Name:     name,
+			PluginID: pluginID,
+			Worker:   wrk,
+		}
+	}
+
+	return nil
+}
+
+// All returns all registered plugin workers sorted by name.
+func (r *WorkerRegistry) All() []WorkerEntry {
+	entries := slices.Collect(maps.Values(r.entries))
+
+	slices.SortFunc(entries, func(a, b WorkerEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return entries
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+)
+
+// BackfillProgressRepository defines the data access contract for BackfillProgress entities.
+type BackfillProgressRepository interface {
+	Insert(ctx context.Context, entity *model.BackfillProgress) error
+	Exists(ctx context.Context, jobID string, periodStart time.Time) (bool, error)
+}
+
+// BackfillProgress is a SQL-based implementation of BackfillProgressRepository.
+type BackfillProgress struct {
+	tx *sqlx.Tx
+}
+
+var _ BackfillProgressRepository = (*BackfillProgress)(nil)
+
+// NewBackfillProgress creates a new BackfillProgress repository instance.
+func NewBackfillProgress(tx *sqlx.Tx) *BackfillProgress {
+	return &BackfillProgress{tx: tx}
+}
+
+// Insert persists a new BackfillProgress record, refreshing the completion time if it already exists.
+func (r *BackfillProgress) Insert(ctx context.Context, entity *model.BackfillProgress) error {
+	query := `
+		INSERT INTO cron_backfill_progress (job_id, period_start)
+		VALUES (:job_id, :period_start)
+		ON CONFLICT (job_id, period_start) DO UPDATE SET completed_at = NOW();
+	`
+
+	_, err := r.tx.NamedExecContext(ctx, query, entity)
+	if err != nil {
+		return fmt.Errorf("inserting BackfillProgress: %w", err)
+	}
+
+	return nil
+}
+
+// Exists reports whether the given period has already been backfilled for the job.
+func (r *BackfillProgress) Exists(
+	ctx context.Context,
+	jobID string,
+	periodStart time.Time,
+) (bool, error) {
+	var exists bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM cron_backfill_progress WHERE job_id = $1 AND period_start = $2
+		);
+	`
+
+	if err := r.tx.GetContext(ctx, &exists, query, jobID, periodStart); err != nil {
+		return false, fmt.Errorf("checking BackfillProgress existence: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+)
+
+// CronJobStateRepository defines the data access contract for CronJobState entities.
+type CronJobStateRepository interface {
+	Upsert(ctx context.Context, entity *model.CronJobState) error
+	GetForUpdate(ctx context.Context, jobID string) (*model.CronJobState, error)
+	List(ctx context.Context) ([]model.CronJobState, error)
+}
+
+// CronJobState is a SQL-based implementation of CronJobStateRepository.
+type CronJobState struct {
+	tx *sqlx.Tx
+}
+
+var _ CronJobStateRepository = (*CronJobState)(nil)
+
+// NewCronJobState creates a new CronJobState repository instance.
+func NewCronJobState(tx *sqlx.Tx) *CronJobState {
+	return &CronJobState{tx: tx}
+}
+
+// Upsert persists a CronJobState record, replacing the existing one for the same job.
+func (r *CronJobState) Upsert(ctx context.Context, entity *model.CronJobState) error {
+	query := `
+		INSERT INTO cron_job_states (
+			job_id, consecutive_failures, last_success_at, last_failure_at, last_error, alerted_at
+		)
+		VALUES (
+			:job_id, :consecutive_failures, :last_success_at, :last_failure_at, :last_error, :alerted_at
+		)
+		ON CONFLICT (job_id) DO UPDATE SET
+			consecutive_failures = EXCLUDED.consecutive_failures,
+			last_success_at      = EXCLUDED.last_success_at,
+			last_failure_at      = EXCLUDED.last_failure_at,
+			last_error           = EXCLUDED.last_error,
+			alerted_at           = EXCLUDED.alerted_at;
+	`
+
+	_, err := r.tx.NamedExecContext(ctx, query, entity)
+	if err != nil {
+		return fmt.Errorf("upserting CronJobState: %w", err)
+	}
+
+	return nil
+}
+
+// GetForUpdate retrieves and locks a CronJobState by its job ID.
+// It returns an empty state for the job if none has been recorded yet.
+func (r *CronJobState) GetForUpdate(
+	ctx context.Context,
+	jobID string,
+) (*model.CronJobState, error) {
+	var entity model.CronJobState
+
+	query := `
+		SELECT job_id, consecutive_failures, last_success_at, last_failure_at, last_error,
+		       alerted_at, created_at, updated_at
+		FROM cron_job_states
+		WHERE job_id = $1
+		FOR UPDATE;
+	`
+
+	err := r.tx.GetContext(ctx, &entity, query, jobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &model.CronJobState{JobID: jobID}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting CronJobState by job ID: %w", err)
+	}
+
+	return &entity, nil
+}
+
+// List retrieves all CronJobState records.
+func (r *CronJobState) List(ctx context.Context) ([]model.CronJobState, error) {
+	var entities []model.CronJobState
+
+	query := `
+		SELECT job_id, consecutive_failures, last_success_at, last_failure_at, last_error,
+		       alerted_at, created_at, updated_at
+		FROM cron_job_states
+		ORDER BY job_id;
+	`
+
+	if err := r.tx.SelectContext(ctx, &entities, query); err != nil {
+		return nil, fmt.Errorf("listing CronJobStates: %w", err)
+	}
+
+	return entities, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+)
+
+const deviceColumns = `
+	id, name, plugin_id, password_hash, topics, last_seen_at, revoked_at, created_at, updated_at
+`
+
+// DeviceRepository defines the data access contract for Device entities.
+type DeviceRepository interface {
+	Insert(ctx context.Context, entity *model.Device) (string, error)
+	GetByName(ctx context.Context, name string) (*model.Device, error)
+	List(ctx context.Context, pluginID string) ([]model.Device, error)
+	Revoke(ctx context.Context, name string) error
+	Touch(ctx context.Context, id string) error
+}
+
+// Device is a SQL-based implementation of DeviceRepository.
+type Device struct {
+	tx *sqlx.Tx
+}
+
+var _ DeviceRepository = (*Device)(nil)
+
+// NewDevice creates a new Device repository instance.
+func NewDevice(tx *sqlx.Tx) *Device {
+	return &Device{tx: tx}
+}
+
+// Insert persists a new Device record and returns its ID.
+// It returns ErrDeviceAlreadyExists if a device with the same name exists.
+func (r *Device) Insert(ctx context.Context, entity *model.Device) (string, error) {
+	var id string
+
+	query := `
+		INSERT INTO devices (name, plugin_id, password_hash, topics)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING id;
+	`
+
+	err := r.tx.GetContext(
+		ctx,
+		&id,
+		query,
+		entity.Name,
+		entity.PluginID,
+		entity.PasswordHash,
+		entity.Topics,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: %s", errs.ErrDeviceAlreadyExists, entity.Name)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("inserting Device: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByName retrieves a Device by its name.
+// It returns ErrDeviceNotFound if no such record exists.
+func (r *Device) GetByName(ctx context.Context, name string) (*model.Device, error) {
+	var entity model.Device
+
+	query := `SELECT ` + deviceColumns + ` FROM devices WHERE name = $1;`
+
+	err := r.tx.GetContext(ctx, &entity, query, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", errs.ErrDeviceNotFound, name)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting Device by name: %w", err)
+	}
+
+	return &entity, nil
+}
+
+// List retrieves all Device records ordered by name.
+// When pluginID is not empty, only the devices of that plugin are returned.
+func (r *Device) List(ctx context.Context, pluginID string) ([]model.Device, error) {
+	var entities []model.Device
+
+	query := `
+		SELECT ` + deviceColumns + `
+		FROM devices
+		WHERE $1 = '' OR plugin_id = $1
+		ORDER BY name;
+	`
+
+	if err := r.tx.SelectContext(ctx, &entities, query, pluginID); err != nil {
+		return nil, fmt.Errorf("listing Devices: %w", err)
+	}
+
+	return entities, nil
+}
+
+// Revoke marks a Device as revoked so that it can no longer authenticate.
+// It returns ErrDeviceNotFound if no such record exists.
+func (r *Device) Revoke(ctx context.Context, name string) error {
+	query := `
+		UPDATE devices
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE name = $1;
+	`
+
+	result, err := r.tx.ExecContext(ctx, query, name)
+	if err != nil {
+		return fmt.Errorf("revoking Device: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("counting revoked Devices: %w", err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%w: %s", errs.ErrDeviceNotFound, name)
+	}
+
+	return nil
+}
+
+// Touch records that a Device has just been seen.
+func (r *Device) Touch(ctx context.Context, id string) error {
+	query := `UPDATE devices SET last_seen_at = NOW() WHERE id = $1;`
+
+	if _, err := r.tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("touching Device: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,2 @@
+// Package repository provides data access abstractions for the core domain.
+package repository
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+)
+
+const mqttDeadLetterColumns = `
+	id, subscription, subscriber_id, topic, relative_topic, payload, last_error, attempts,
+	created_at, updated_at
+`
+
+// MQTTDeadLetterRepository defines the data access contract for MQTTDeadLetter entities.
+type MQTTDeadLetterRepository interface {
+	Insert(ctx context.Context, entity *model.MQTTDeadLetter) (string, error)
+	Get(ctx context.Context, id string) (*model.MQTTDeadLetter, error)
+	List(ctx context.Context, subscriberID string, limit int) ([]model.MQTTDeadLetter, error)
+	RecordFailure(ctx context.Context, id string, lastError string) error
+	Delete(ctx context.Context, id string) error
+	Purge(ctx context.Context, subscriberID string, before time.Time) (int64, error)
+}
+
+// MQTTDeadLetter is a SQL-based implementation of MQTTDeadLetterRepository.
+type MQTTDeadLetter struct {
+	tx *sqlx.Tx
+}
+
+var _ MQTTDeadLetterRepository = (*MQTTDeadLetter)(nil)
+
+// NewMQTTDeadLetter creates a new MQTTDeadLetter repository instance.
+func NewMQTTDeadLetter(tx *sqlx.Tx) *MQTTDeadLetter {
+	return &MQTTDeadLetter{tx: tx}
+}
+
+// Insert persists a new MQTTDeadLetter record and returns its ID.
+func (r *MQTTDeadLetter) Insert(ctx context.Context, entity *model.MQTTDeadLetter) (string, error) {
+	var id string
+
+	query := `
+		INSERT INTO mqtt_dead_letters (
+			subscription, subscriber_id, topic, relative_topic, payload, last_error
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id;
+	`
+
+	err := r.tx.GetContext(
+		ctx,
+		&id,
+		query,
+		entity.Subscription,
+		entity.SubscriberID,
+		entity.Topic,
+		entity.RelativeTopic,
+		entity.Payload,
+		entity.LastError,
+	)
+	if err != nil {
+		return "", fmt.Errorf("inserting MQTTDeadLetter: %w", err)
+	}
+
+	return id, nil
+}
+
+// Get retrieves an MQTTDeadLetter by its ID.
+// It returns ErrMQTTDeadLetterNotFound if no such record exists.
+func (r *MQTTDeadLetter) Get(ctx context.Context, id string) (*model.MQTTDeadLetter, error) {
+	var entity model.MQTTDeadLetter
+
+	query := `SELECT ` + mqttDeadLetterColumns + ` FROM mqtt_dead_letters WHERE id = $1;`
+
+	err := r.tx.GetContext(ctx, &entity, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", errs.ErrMQTTDeadLetterNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting MQTTDeadLetter by ID: %w", err)
+	}
+
+	return &entity, nil
+}
+
+// List retrieves up to limit MQTTDeadLetter records, oldest first.
+// When subscriberID is not empty, only the records of that subscriber are returned.
+func (r *MQTTDeadLetter) List(
+	ctx context.Context,
+	subscriberID string,
+	limit int,
+) ([]model.MQTTDeadLetter, error) {
+	var entities []model.MQTTDeadLetter
+
+	query := `
+		SELECT ` + mqttDeadLetterColumns + `
+		FROM mqtt_dead_letters
+		WHERE $1 = '' OR subscriber_id = $1
+		ORDER BY created_at
+		LIMIT $2;
+	`
+
+	if err := r.tx.SelectContext(ctx, &entities, query, subscriberID, limit); err != nil {
+		return nil, fmt.Errorf("listing MQTTDeadLetters: %w", err)
+	}
+
+	return entities, nil
+}
+
+// RecordFailure increments the attempt count of an MQTTDeadLetter and stores the latest error.
+func (r *MQTTDeadLetter) RecordFailure(ctx context.Context, id string, lastError string) error {
+	query := `
+		UPDATE mqtt_dead_letters
+		SET attempts   = attempts + 1,
+		    last_error = $2
+		WHERE id = $1;
+	`
+
+	if _, err := r.tx.ExecContext(ctx, query, id, lastError); err != nil {
+		return fmt.Errorf("recording MQTTDeadLetter failure: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes an MQTTDeadLetter by its ID.
+func (r *MQTTDeadLetter) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM mqtt_dead_letters WHERE id = $1;`
+
+	if _, err := r.tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("deleting MQTTDeadLetter: %w", err)
+	}
+
+	return nil
+}
+
+// Purge removes MQTTDeadLetter records created before the given time and returns their count.
+// When subscriberID is not empty, only the records of that subscriber are removed.
+func (r *MQTTDeadLetter) Purge(
+	ctx context.Context,
+	subscriberID string,
+	before time.Time,
+) (int64, error) {
+	query := `
+		DELETE FROM mqtt_dead_letters
+		WHERE ($1 = '' OR subscriber_id = $1) AND created_at < $2;
+	`
+
+	result, err := r.tx.ExecContext(ctx, query, subscriberID, before)
+	if err != nil {
+		return 0, fmt.Errorf("purging MQTTDeadLetters: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("counting purged MQTTDeadLetters: %w", err)
+	}
+
+	return count, nil
+}
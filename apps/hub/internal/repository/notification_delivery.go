@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+)
+
+const notificationDeliveryColumns = `
+	id, message_id, transport, fallback, status, attempts, run_at, locked_until, last_error,
+	delivered_at, created_at, updated_at
+`
+
+// NotificationDeliveryRepository defines the data access contract for NotificationDelivery entities.
+type NotificationDeliveryRepository interface {
+	InsertMany(ctx context.Context, messageID string, transports []string, fallback bool) error
+	ClaimDue(
+		ctx context.Context,
+		limit int,
+		lockedUntil time.Time,
+	) ([]model.NotificationDelivery, error)
+	ListByMessages(ctx context.Context, messageIDs []string) ([]model.NotificationDelivery, error)
+	CountNotFailed(ctx context.Context, messageID string, fallback bool) (int, error)
+	MarkDelivered(ctx context.Context, entity *model.NotificationDelivery) error
+	MarkFailed(ctx context.Context, entity *model.NotificationDelivery, lastError string) error
+	Retry(
+		ctx context.Context,
+		entity *model.NotificationDelivery,
+		runAt time.Time,
+		lastError string,
+	) error
+	ResetFailed(ctx context.Context, messageID string) (int64, error)
+}
+
+// NotificationDelivery is a SQL-based implementation of NotificationDeliveryRepository.
+type NotificationDelivery struct {
+	tx *sqlx.Tx
+}
+
+var _ NotificationDeliveryRepository = (*NotificationDelivery)(nil)
+
+// NewNotificationDelivery creates a new NotificationDelivery repository instance.
+func NewNotificationDelivery(tx *sqlx.Tx) *NotificationDelivery {
+	return &NotificationDelivery{tx: tx}
+}
+
+// InsertMany persists a pending NotificationDelivery for every transport of a message.
+// Deliveries that already exist are left untouched.
+func (r *NotificationDelivery) InsertMany(
+	ctx context.Context,
+	messageID string,
+	transports []string,
+	fallback bool,
+) error {
+	query := `
+		INSERT INTO notification_deliveries (message_id, transport, fallback)
+		SELECT $1, transport, $3
+		FROM UNNEST($2::TEXT[]) AS transport
+		ON CONFLICT (message_id, transport, fallback) DO NOTHING;
+	`
+
+	_, err := r.tx.ExecContext(ctx, query, messageID, pq.StringArray(transports), fallback)
+	if err != nil {
+		return fmt.Errorf("inserting NotificationDeliveries: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue marks up to limit due deliveries as running, locks them until lockedUntil
+// and returns them. Running deliveries whose lock expired are claimed again.
+func (r *NotificationDelivery) ClaimDue(
+	ctx context.Context,
+	limit int,
+	lockedUntil time.Time,
+) ([]model.NotificationDelivery, error) {
+	var entities []model.NotificationDelivery
+
+	query := `
+		UPDATE notification_deliveries
+		SET status       = 'running',
+		    attempts     = attempts + 1,
+		    locked_until = $2
+		WHERE id IN (
+			SELECT id
+			FROM notification_deliveries
+			WHERE (status = 'pending' AND run_at <= NOW())
+			   OR (status = 'running' AND locked_until < NOW())
+			ORDER BY run_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + notificationDeliveryColumns + `;`
+
+	if err := r.tx.SelectContext(ctx, &entities, query, limit, lockedUntil); err != nil {
+		return nil, fmt.Errorf("claiming due NotificationDeliveries: %w", err)
+	}
+
+	return entities, nil
+}
+
+// ListByMessages retrieves the NotificationDelivery records of the given messages.
+func (r *NotificationDelivery) ListByMessages(
+	ctx context.Context,
+	messageIDs []string,
+) ([]model.NotificationDelivery, error) {
+	var entities []model.NotificationDelivery
+
+	query := `
+		SELECT ` + notificationDeliveryColumns + `
+		FROM notification_deliveries
+		WHERE message_id = ANY($1::UUID[])
+		ORDER BY fallback, transport;
+	`
+
+	err := r.tx.SelectContext(ctx, &entities, query, pq.StringArray(messageIDs))
+	if err != nil {
+		return nil, fmt.Errorf("listing NotificationDeliveries by messages: %w", err)
+	}
+
+	return entities, nil
+}
+
+// CountNotFailed counts the primary or fallback deliveries of a message that have not failed.
+func (r *NotificationDelivery) CountNotFailed(
+	ctx context.Context,
+	messageID string,
+	fallback bool,
+) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM notification_deliveries
+		WHERE message_id = $1 AND fallback = $2 AND status <> 'failed';
+	`
+
+	if err := r.tx.GetContext(ctx, &count, query, messageID, fallback); err != nil {
+		return 0, fmt.Errorf("counting NotificationDeliveries: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkDelivered completes a claimed delivery successfully.
+func (r *NotificationDelivery) MarkDelivered(
+	ctx context.Context,
+	entity *model.NotificationDelivery,
+) error {
+	query := `
+		UPDATE notification_deliveries
+		SET status       = 'delivered',
+		    locked_until = NULL,
+		    last_error   = NULL,
+		    delivered_at = NOW()
+		WHERE id = $1 AND attempts = $2;
+	`
+
+	if _, err := r.tx.ExecContext(ctx, query, entity.ID, entity.Attempts); err != nil {
+		return fmt.Errorf("marking NotificationDelivery as delivered: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed completes a claimed delivery that exhausted its attempts.
+func (r *NotificationDelivery) MarkFailed(
+	ctx context.Context,
+	entity *model.NotificationDelivery,
+	lastError string,
+) error {
+	query := `
+		UPDATE notification_deliveries
+		SET status       = 'failed',
+		    locked_until = NULL,
+		    last_error   = $3
+		WHERE id = $1 AND attempts = $2;
+	`
+
+	if _, err := r.tx.ExecContext(ctx, query, entity.ID, entity.Attempts, lastError); err != nil {
+		return fmt.Errorf("marking NotificationDelivery as failed: %w", err)
+	}
+
+	return nil
+}
+
+// Retry returns a claimed delivery to the outbox to be attempted again at runAt.
+func (r *NotificationDelivery) Retry(
+	ctx context.Context,
+	entity *model.NotificationDelivery,
+	runAt time.Time,
+	lastError string,
+) error {
+	query := `
+		UPDATE notification_deliveries
+		SET status       = 'pending',
+		    locked_until = NULL,
+		    run_at       = $3,
+		    last_error   = $4
+		WHERE id = $1 AND attempts = $2;
+	`
+
+	_, err := r.tx.ExecContext(ctx, query, entity.ID, entity.Attempts, runAt, lastError)
+	if err != nil {
+		return fmt.Errorf("retrying NotificationDelivery: %w", err)
+	}
+
+	return nil
+}
+
+// ResetFailed returns the failed deliveries of a message to the outbox with a fresh
+// attempt budget and returns their count.
+func (r *NotificationDelivery) ResetFailed(ctx context.Context, messageID string) (int64, error) {
+	query := `
+		UPDATE notification_deliveries
+		SET status       = 'pending',
+		    attempts     = 0,
+		    run_at       = NOW(),
+		    locked_until = NULL
+		WHERE message_id = $1 AND status = 'failed';
+	`
+
+	result, err := r.tx.ExecContext(ctx, query, messageID)
+	if err != nil {
+		return 0, fmt.Errorf("resetting failed NotificationDeliveries: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("counting reset NotificationDeliveries: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+)
+
+const notificationMessageColumns = `id, channel, message, created_at, updated_at`
+
+// NotificationMessageRepository defines the data access contract for NotificationMessage entities.
+type NotificationMessageRepository interface {
+	Insert(ctx context.Context, entity *model.NotificationMessage) (string, error)
+	Get(ctx context.Context, id string) (*model.NotificationMessage, error)
+	ListUndelivered(
+		ctx context.Context,
+		status model.NotificationDeliveryStatus,
+		limit int,
+	) ([]model.NotificationMessage, error)
+}
+
+// NotificationMessage is a SQL-based implementation of NotificationMessageRepository.
+type NotificationMessage struct {
+	tx *sqlx.Tx
+}
+
+var _ NotificationMessageRepository = (*NotificationMessage)(nil)
+
+// NewNotificationMessage creates a new NotificationMessage repository instance.
+func NewNotificationMessage(tx *sqlx.Tx) *NotificationMessage {
+	return &NotificationMessage{tx: tx}
+}
+
+// Insert persists a new NotificationMessage record and returns its ID.
+func (r *NotificationMessage) Insert(
+	ctx context.Context,
+	entity *model.NotificationMessage,
+) (string, error) {
+	var id string
+
+	query := `
+		INSERT INTO notification_messages (channel, message)
+		VALUES ($1, $2)
+		RETURNING id;
+	`
+
+	// The JSONB message is passed as text, since drivers may encode byte slices as bytea.
+	err := r.tx.GetContext(ctx, &id, query, entity.Channel, string(entity.Message))
+	if err != nil {
+		return "", fmt.Errorf("inserting NotificationMessage: %w", err)
+	}
+
+	return id, nil
+}
+
+// Get retrieves a NotificationMessage by its ID.
+// It returns ErrNotificationNotFound if no such record exists.
+func (r *NotificationMessage) Get(
+	ctx context.Context,
+	id string,
+) (*model.NotificationMessage, error) {
+	var entity model.NotificationMessage
+
+	query := `SELECT ` + notificationMessageColumns + ` FROM notification_messages WHERE id = $1;`
+
+	err := r.tx.GetContext(ctx, &entity, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", errs.ErrNotificationNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting NotificationMessage by ID: %w", err)
+	}
+
+	return &entity, nil
+}
+
+// ListUndelivered retrieves up to limit NotificationMessage records, oldest first,
+// that have a delivery in the given status. An empty status matches every delivery
+// that has not been delivered yet.
+func (r *NotificationMessage) ListUndelivered(
+	ctx context.Context,
+	status model.NotificationDeliveryStatus,
+	limit int,
+) ([]model.NotificationMessage, error) {
+	var entities []model.NotificationMessage
+
+	query := `
+		SELECT ` + notificationMessageColumns + `
+		FROM notification_messages m
+		WHERE EXISTS (
+			SELECT 1
+			FROM notification_deliveries d
+			WHERE d.message_id = m.id
+			  AND (($1 = '' AND d.status <> 'delivered') OR d.status::TEXT = $1)
+		)
+		ORDER BY created_at
+		LIMIT $2;
+	`
+
+	if err := r.tx.SelectContext(ctx, &entities, query, status, limit); err != nil {
+		return nil, fmt.Errorf("listing undelivered NotificationMessages: %w", err)
+	}
+
+	return entities, nil
+}
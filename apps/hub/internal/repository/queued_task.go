@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+)
+
+const queuedTaskColumns = `
+	id, type, payload, status, unique_key, attempts, max_attempts, backoff_ns, run_at,
+	locked_until, last_error, completed_at, created_at, updated_at
+`
+
+// QueuedTaskRepository defines the data access contract for QueuedTask entities.
+type QueuedTaskRepository interface {
+	Insert(ctx context.Context, entity *model.QueuedTask) (string, error)
+	ClaimDue(ctx context.Context, limit int, lockedUntil time.Time) ([]model.QueuedTask, error)
+	MarkSucceeded(ctx context.Context, entity *model.QueuedTask) error
+	MarkFailed(ctx context.Context, entity *model.QueuedTask, lastError string) error
+	Retry(ctx context.Context, entity *model.QueuedTask, runAt time.Time, lastError string) error
+}
+
+// QueuedTask is a SQL-based implementation of QueuedTaskRepository.
+type QueuedTask struct {
+	tx *sqlx.Tx
+}
+
+var _ QueuedTaskRepository = (*QueuedTask)(nil)
+
+// NewQueuedTask creates a new QueuedTask repository instance.
+func NewQueuedTask(tx *sqlx.Tx) *QueuedTask {
+	return &QueuedTask{tx: tx}
+}
+
+// Insert persists a new QueuedTask record and returns its ID.
+// If an active task of the same type with the same unique key exists,
+// no record is inserted and the ID of the existing task is returned.
+func (r *QueuedTask) Insert(ctx context.Context, entity *model.QueuedTask) (string, error) {
+	var id string
+
+	query := `
+		INSERT INTO queued_tasks (type, payload, unique_key, max_attempts, backoff_ns, run_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (type, unique_key)
+			WHERE unique_key IS NOT NULL AND status IN ('pending', 'running')
+			DO NOTHING
+		RETURNING id;
+	`
+
+	err := r.tx.GetContext(
+		ctx,
+		&id,
+		query,
+		entity.Type,
+		entity.Payload,
+		entity.UniqueKey,
+		entity.MaxAttempts,
+		entity.Backoff,
+		entity.RunAt,
+	)
+	if err == nil {
+		return id, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("inserting QueuedTask: %w", err)
+	}
+
+	query = `
+		SELECT id
+		FROM queued_tasks
+		WHERE type = $1 AND unique_key = $2 AND status IN ('pending', 'running');
+	`
+
+	if err = r.tx.GetContext(ctx, &id, query, entity.Type, entity.UniqueKey); err != nil {
+		return "", fmt.Errorf("getting QueuedTask by unique key: %w", err)
+	}
+
+	return id, nil
+}
+
+// ClaimDue marks up to limit due tasks as running, locks them until lockedUntil and returns them.
+// Running tasks whose lock expired (e.g. after a worker crash) are claimed again.
+func (r *QueuedTask) ClaimDue(
+	ctx context.Context,
+	limit int,
+	lockedUntil time.Time,
+) ([]model.QueuedTask, error) {
+	var entities []model.QueuedTask
+
+	query := `
+		UPDATE queued_tasks
+		SET status       = 'running',
+		    attempts     = attempts + 1,
+		    locked_until = $2
+		WHERE id IN (
+			SELECT id
+			FROM queued_tasks
+			WHERE (status = 'pending' AND run_at <= NOW())
+			   OR (status = 'running' AND locked_until < NOW())
+			ORDER BY run_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + queuedTaskColumns + `;`
+
+	if err := r.tx.SelectContext(ctx, &entities, query, limit, lockedUntil); err != nil {
+		return nil, fmt.Errorf("claiming due QueuedTasks: %w", err)
+	}
+
+	return entities, nil
+}
+
+// MarkSucceeded completes a claimed task successfully.
+func (r *QueuedTask) MarkSucceeded(ctx context.Context, entity *model.QueuedTask) error {
+	query := `
+		UPDATE queued_tasks
+		SET status       = 'succeeded',
+		    locked_until = NULL,
+		    last_error   = NULL,
+		    completed_at = NOW()
+		WHERE id = $1 AND attempts = $2;
+	`
+
+	if _, err := r.tx.ExecContext(ctx, query, entity.ID, entity.Attempts); err != nil {
+		return fmt.Errorf("marking QueuedTask as succeeded: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed completes a claimed task that exhausted its attempts.
+func (r *QueuedTask) MarkFailed(
+	ctx context.Context,
+	entity *model.QueuedTask,
+	lastError string,
+) error {
+	query := `
+		UPDATE queued_tasks
+		SET status       = 'failed',
+		    locked_until = NULL,
+		    last_error   = $3,
+		    completed_at = NOW()
+		WHERE id = $1 AND attempts = $2;
+	`
+
+	if _, err := r.tx.ExecContext(ctx, query, entity.ID, entity.Attempts, lastError); err != nil {
+		return fmt.Errorf("marking QueuedTask as failed: %w", err)
+	}
+
+	return nil
+}
+
+// Retry returns a claimed task to the queue to be attempted again at runAt.
+func (r *QueuedTask) Retry(
+	ctx context.Context,
+	entity *model.QueuedTask,
+	runAt time.Time,
+	lastError string,
+) error {
+	query := `
+		UPDATE queued_tasks
+		SET status       = 'pending',
+		    locked_until = NULL,
+		    run_at       = $3,
+		    last_error   = $4
+		WHERE id = $1 AND attempts = $2;
+	`
+
+	_, err := r.tx.ExecContext(ctx, query, entity.ID, entity.Attempts, runAt, lastError)
+	if err != nil {
+		return fmt.Errorf("retrying QueuedTask: %w", err)
+	}
+
+	return nil
+}
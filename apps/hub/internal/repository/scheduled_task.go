@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+)
+
+const scheduledTaskColumns = `
+	id, handler, key, payload, run_at, every_ns, locked_until, run_count,
+	last_run_at, last_error, attempts, failed_at, created_at, updated_at
+`
+
+// ScheduledTaskRepository defines the data access contract for ScheduledTask entities.
+type ScheduledTaskRepository interface {
+	Upsert(ctx context.Context, entity *model.ScheduledTask) (string, error)
+	Delete(ctx context.Context, id string) error
+	DeleteByKey(ctx context.Context, handler string, key string) error
+	ClaimDue(ctx context.Context, limit int, lockedUntil time.Time) ([]model.ScheduledTask, error)
+	Complete(ctx context.Context, entity *model.ScheduledTask, nextRunAt *time.Time) error
+	Retry(ctx context.Context, entity *model.ScheduledTask, runAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, entity *model.ScheduledTask, lastError string) error
+}
+
+// ScheduledTask is a SQL-based implementation of ScheduledTaskRepository.
+type ScheduledTask struct {
+	tx *sqlx.Tx
+}
+
+var _ ScheduledTaskRepository = (*ScheduledTask)(nil)
+
+// NewScheduledTask creates a new ScheduledTask repository instance.
+func NewScheduledTask(tx *sqlx.Tx) *ScheduledTask {
+	return &ScheduledTask{tx: tx}
+}
+
+// Upsert persists a ScheduledTask record and returns its ID.
+// A task with the same handler and key replaces the existing one, including a failed one.
+func (r *ScheduledTask) Upsert(ctx context.Context, entity *model.ScheduledTask) (string, error) {
+	query := `
+		INSERT INTO scheduled_tasks (handler, key, payload, run_at, every_ns)
+		VALUES (:handler, :key, :payload, :run_at, :every_ns)
+		ON CONFLICT (handler, key) DO UPDATE SET
+			payload      = EXCLUDED.payload,
+			run_at       = EXCLUDED.run_at,
+			every_ns     = EXCLUDED.every_ns,
+			locked_until = NULL,
+			attempts     = 0,
+			failed_at    = NULL,
+			last_error   = NULL
+		RETURNING id;
+	`
+
+	rows, err := sqlx.NamedQueryContext(ctx, r.tx, query, entity)
+	if err != nil {
+		return "", fmt.Errorf("upserting ScheduledTask: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var id string
+
+	if rows.Next() {
+		if err = rows.Scan(&id); err != nil {
+			return "", fmt.Errorf("scanning ScheduledTask ID: %w", err)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return "", fmt.Errorf("upserting ScheduledTask: %w", err)
+	}
+
+	return id, nil
+}
+
+// Delete removes a ScheduledTask by its ID.
+func (r *ScheduledTask) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM scheduled_tasks WHERE id = $1;`
+
+	if _, err := r.tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("deleting ScheduledTask: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByKey removes a ScheduledTask by its handler and key.
+func (r *ScheduledTask) DeleteByKey(ctx context.Context, handler string, key string) error {
+	query := `DELETE FROM scheduled_tasks WHERE handler = $1 AND key = $2;`
+
+	if _, err := r.tx.ExecContext(ctx, query, handler, key); err != nil {
+		return fmt.Errorf("deleting ScheduledTask by key: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue locks up to limit due tasks until lockedUntil and returns them.
+// Tasks locked by another worker and failed tasks are skipped.
+func (r *ScheduledTask) ClaimDue(
+	ctx context.Context,
+	limit int,
+	lockedUntil time.Time,
+) ([]model.ScheduledTask, error) {
+	var entities []model.ScheduledTask
+
+	query := `
+		UPDATE scheduled_tasks
+		SET locked_until = $2
+		WHERE id IN (
+			SELECT id
+			FROM scheduled_tasks
+			WHERE run_at <= NOW()
+			  AND failed_at IS NULL
+			  AND (locked_until IS NULL OR locked_until < NOW())
+			ORDER BY run_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + scheduledTaskColumns + `;`
+
+	if err := r.tx.SelectContext(ctx, &entities, query, limit, lockedUntil); err != nil {
+		return nil, fmt.Errorf("claiming due ScheduledTasks: %w", err)
+	}
+
+	return entities, nil
+}
+
+// Complete records a finished run of a claimed task. A one-off task (nil nextRunAt) is removed,
+// a recurring one is unlocked and moved to nextRunAt. Tasks rescheduled while running are left intact.
+func (r *ScheduledTask) Complete(
+	ctx context.Context,
+	entity *model.ScheduledTask,
+	nextRunAt *time.Time,
+) error {
+	if nextRunAt == nil {
+		query := `DELETE FROM scheduled_tasks WHERE id = $1 AND run_at = $2;`
+
+		if _, err := r.tx.ExecContext(ctx, query, entity.ID, entity.RunAt); err != nil {
+			return fmt.Errorf("completing ScheduledTask: %w", err)
+		}
+
+		return nil
+	}
+
+	query := `
+		UPDATE scheduled_tasks
+		SET run_at       = $3,
+		    locked_until = NULL,
+		    run_count    = run_count + 1,
+		    last_run_at  = NOW(),
+		    last_error   = $4
+		WHERE id = $1 AND run_at = $2;
+	`
+
+	_, err := r.tx.ExecContext(ctx, query, entity.ID, entity.RunAt, *nextRunAt, entity.LastError)
+	if err != nil {
+		return fmt.Errorf("completing ScheduledTask: %w", err)
+	}
+
+	return nil
+}
+
+// Retry records a failed run of a claimed one-off task and moves it to runAt.
+// Tasks rescheduled while running are left intact.
+func (r *ScheduledTask) Retry(
+	ctx context.Context,
+	entity *model.ScheduledTask,
+	runAt time.Time,
+	lastError string,
+) error {
+	query := `
+		UPDATE scheduled_tasks
+		SET run_at       = $3,
+		    locked_until = NULL,
+		    attempts     = attempts + 1,
+		    last_run_at  = NOW(),
+		    last_error   = $4
+		WHERE id = $1 AND run_at = $2;
+	`
+
+	if _, err := r.tx.ExecContext(ctx, query, entity.ID, entity.RunAt, runAt, lastError); err != nil {
+		return fmt.Errorf("retrying ScheduledTask: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed records the last failed run of a claimed one-off task. The task is kept with
+// its error but no longer claimed. Tasks rescheduled while running are left intact.
+func (r *ScheduledTask) MarkFailed(
+	ctx context.Context,
+	entity *model.ScheduledTask,
+	lastError string,
+) error {
+	query := `
+		UPDATE scheduled_tasks
+		SET failed_at    = NOW(),
+		    locked_until = NULL,
+		    attempts     = attempts + 1,
+		    last_run_at  = NOW(),
+		    last_error   = $3
+		WHERE id = $1 AND run_at = $2;
+	`
+
+	if _, err := r.tx.ExecContext(ctx, query, entity.ID, entity.RunAt, lastError); err != nil {
+		return fmt.Errorf("marking ScheduledTask as failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+// Package scheduler provides the runtime task scheduler exposed to plugins through Host.Scheduler().
+// Tasks are persisted in Postgres and executed by the scheduler worker.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/apps/hub/internal/backoff"
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/database"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/apps/hub/internal/repository"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// Scheduler persists delayed and recurring tasks scheduled by plugins.
+type Scheduler struct {
+	cfg      config.Scheduler
+	logger   *slog.Logger
+	db       *sqlx.DB
+	registry *registry.ScheduledTaskRegistry
+}
+
+var _ pluginapi.Scheduler = (*Scheduler)(nil)
+
+// New creates a new Scheduler.
+func New(
+	cfg *config.Config,
+	logger *slog.Logger,
+	db *sqlx.DB,
+	scheduledTaskRegistry *registry.ScheduledTaskRegistry,
+) *Scheduler {
+	return &Scheduler{
+		cfg: cfg.Scheduler,
+		logger: logger.With(
+			slog.String("component", "scheduler"),
+		),
+		db:       db,
+		registry: scheduledTaskRegistry,
+	}
+}
+
+// Schedule persists the task and returns its ID.
+// It returns an error if no handler is registered for the task.
+func (s *Scheduler) Schedule(ctx context.Context, task pluginapi.ScheduledTask) (string, error) {
+	if _, ok := s.registry.Get(task.Handler); !ok {
+		return "", fmt.Errorf("%w: %s", errs.ErrScheduledTaskHandlerNotFound, task.Handler)
+	}
+
+	if task.Every < 0 {
+		return "", fmt.Errorf("%w: negative interval %s", errs.ErrInvalidScheduledTask, task.Every)
+	}
+
+	entity := &model.ScheduledTask{
+		Handler: task.Handler,
+		Payload: task.Payload,
+		RunAt:   task.RunAt,
+		Every:   task.Every,
+	}
+
+	if entity.RunAt.IsZero() {
+		entity.RunAt = time.Now()
+	}
+
+	if task.Key != "" {
+		entity.Key = &task.Key
+	}
+
+	var id string
+
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		id, err = repository.NewScheduledTask(tx).Upsert(ctx, entity)
+
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("scheduling task: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "task scheduled",
+		slog.String("task_id", id),
+		slog.String("handler", task.Handler),
+		slog.String("key", task.Key),
+		slog.Time("run_at", entity.RunAt),
+		slog.Duration("every", entity.Every),
+		slog.Bool("dry_run", pluginapi.IsDryRun(ctx)),
+	)
+
+	return id, nil
+}
+
+// Cancel removes the task with the given ID.
+func (s *Scheduler) Cancel(ctx context.Context, id string) error {
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		return repository.NewScheduledTask(tx).Delete(ctx, id)
+	})
+	if err != nil {
+		return fmt.Errorf("cancelling task: %w", err)
+	}
+
+	return nil
+}
+
+// CancelByKey removes the task with the given handler and key.
+func (s *Scheduler) CancelByKey(ctx context.Context, handler string, key string) error {
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		return repository.NewScheduledTask(tx).DeleteByKey(ctx, handler, key)
+	})
+	if err != nil {
+		return fmt.Errorf("cancelling task by key: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue locks up to limit due tasks for the lease duration and returns them.
+func (s *Scheduler) ClaimDue(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]model.ScheduledTask, error) {
+	var tasks []model.ScheduledTask
+
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		var err error
+
+		tasks, err = repository.NewScheduledTask(tx).ClaimDue(ctx, limit, time.Now().Add(lease))
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claiming due tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// Run executes a claimed task with its handler and records the outcome.
+// Recurring tasks are moved to their next run time, skipping runs missed while the worker was down.
+// One-off tasks are removed once they succeed; failed ones are retried with exponential backoff
+// and kept as failed, with their last error, once they exhaust their attempts.
+func (s *Scheduler) Run(ctx context.Context, task *model.ScheduledTask) {
+	logger := s.logger.With(
+		slog.String("task_id", task.ID),
+		slog.String("handler", task.Handler),
+	)
+
+	runErr := s.execute(ctx, task)
+	if runErr != nil {
+		errText := runErr.Error()
+		task.LastError = &errText
+	} else {
+		task.LastError = nil
+
+		logger.InfoContext(ctx, "scheduled task execution completed successfully")
+	}
+
+	err := database.WithTx(ctx, s.db, func(tx *sqlx.Tx) error {
+		repo := repository.NewScheduledTask(tx)
+
+		switch {
+		case task.Every > 0:
+			if runErr != nil {
+				logger.ErrorContext(ctx, "scheduled task execution failed", slog.Any("error", runErr))
+			}
+
+			next := nextRun(task.RunAt, task.Every, time.Now())
+
+			return repo.Complete(ctx, task, &next)
+		case runErr == nil:
+			return repo.Complete(ctx, task, nil)
+		case task.Attempts+1 >= s.cfg.MaxAttempts:
+			logger.ErrorContext(ctx, "scheduled task failed permanently",
+				slog.Int("attempt", task.Attempts+1),
+				slog.Any("error", runErr),
+			)
+
+			return repo.MarkFailed(ctx, task, runErr.Error())
+		default:
+			runAt := time.Now().Add(s.backoff(task))
+
+			logger.WarnContext(ctx, "scheduled task failed, retry scheduled",
+				slog.Int("attempt", task.Attempts+1),
+				slog.Int("max_attempts", s.cfg.MaxAttempts),
+				slog.Time("retry_at", runAt),
+				slog.Any("error", runErr),
+			)
+
+			return repo.Retry(ctx, task, runAt, runErr.Error())
+		}
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "recording scheduled task run failed", slog.Any("error", err))
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, task *model.ScheduledTask) error {
+	handler, ok := s.registry.Get(task.Handler)
+	if !ok {
+		return fmt.Errorf("%w: %s", errs.ErrScheduledTaskHandlerNotFound, task.Handler)
+	}
+
+	return handler.Run(ctx, task.Payload) //nolint:wrapcheck
+}
+
+// backoff returns the delay before retrying a one-off task after its failed run.
+func (s *Scheduler) backoff(task *model.ScheduledTask) time.Duration {
+	return backoff.Exponential(s.cfg.Backoff, s.cfg.MaxBackoff, task.Attempts)
+}
+
+func nextRun(runAt time.Time, every time.Duration, now time.Time) time.Time {
+	next := runAt.Add(every)
+	if next.After(now) {
+		return next
+	}
+
+	missed := now.Sub(runAt) / every
+
+	return runAt.Add((missed + 1) * every)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/robfig/cron/v3"
 
+	"github.com/abgeo/maroid/apps/hub/internal/alert"
+	"github.com/abgeo/maroid/apps/hub/internal/cronschedule"
 	"github.com/abgeo/maroid/apps/hub/internal/registry"
 )
 
@@ -14,7 +17,9 @@ import (
 type CronWorker struct {
 	logger       *slog.Logger
 	scheduler    *cron.Cron
+	parser       *cronschedule.Parser
 	cronRegistry *registry.CronRegistry
+	alertPolicy  *alert.CronPolicy
 }
 
 var _ Worker = (*CronWorker)(nil)
@@ -23,7 +28,9 @@ var _ Worker = (*CronWorker)(nil)
 func NewCronWorker(
 	logger *slog.Logger,
 	scheduler *cron.Cron,
+	parser *cronschedule.Parser,
 	cronRegistry *registry.CronRegistry,
+	alertPolicy *alert.CronPolicy,
 ) *CronWorker {
 	return &CronWorker{
 		logger: logger.With(
@@ -31,7 +38,9 @@ func NewCronWorker(
 			slog.String("worker", "cron"),
 		),
 		scheduler:    scheduler,
+		parser:       parser,
 		cronRegistry: cronRegistry,
+		alertPolicy:  alertPolicy,
 	}
 }
 
@@ -45,17 +54,21 @@ func (w *CronWorker) Prepare() error {
 
 		logger := w.logger.With(slog.String("job_id", meta.ID))
 
-		baseJob := cron.FuncJob(wrapCronJob(logger, job.Run))
+		baseJob := cron.FuncJob(wrapCronJob(logger, meta.ID, job.Run, w.alertPolicy))
 		skippingJob := cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(baseJob)
 
-		entryID, err := w.scheduler.AddJob(meta.Schedule, skippingJob)
+		schedule, err := w.parser.Parse(meta)
 		if err != nil {
 			return fmt.Errorf("scheduling cron job %s: %w", meta.ID, err)
 		}
 
+		entryID := w.scheduler.Schedule(schedule, skippingJob)
+
 		logger.Info(
 			"cron job registered successfully",
-			slog.String("schedule", meta.Schedule),
+			slog.String("schedule", schedule.Spec),
+			slog.String("timezone", schedule.Location.String()),
+			slog.Time("next_run", schedule.Next(time.Now())),
 			slog.Int("entry_id", int(entryID)),
 		)
 	}
@@ -95,7 +108,12 @@ func (w *CronWorker) Stop(ctx context.Context) error {
 	return nil
 }
 
-func wrapCronJob(logger *slog.Logger, jobFunc func(ctx context.Context) error) func() {
+func wrapCronJob(
+	logger *slog.Logger,
+	jobID string,
+	jobFunc func(ctx context.Context) error,
+	alertPolicy *alert.CronPolicy,
+) func() {
 	return func() {
 		ctx := context.Background()
 
@@ -103,10 +121,12 @@ func wrapCronJob(logger *slog.Logger, jobFunc func(ctx context.Context) error) f
 
 		if err := jobFunc(ctx); err != nil {
 			logger.ErrorContext(ctx, "cron job execution failed", slog.Any("error", err))
+			alertPolicy.RecordFailure(ctx, jobID, err)
 
 			return
 		}
 
 		logger.InfoContext(ctx, "cron job execution completed successfully")
+		alertPolicy.RecordSuccess(ctx, jobID)
 	}
 }
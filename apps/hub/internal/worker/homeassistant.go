@@ -0,0 +1,138 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/homeassistant"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttbroker"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+)
+
+// HomeAssistantWorker announces plugin entities to Home Assistant over MQTT
+// and keeps their states up to date.
+// With the embedded broker, it connects in-process once the MQTT worker has started the broker;
+// until then Start fails and the supervisor retries it. An external broker connection registers
+// the bridge's last will, so the entities turn unavailable when the hub dies without a clean Stop;
+// the in-process connection shares the broker's lifetime and needs none.
+type HomeAssistantWorker struct {
+	logger *slog.Logger
+	cfg    *config.Config
+	bridge *homeassistant.Bridge
+	// broker is nil unless the embedded broker is enabled.
+	broker *mqttbroker.Broker
+
+	conn mqttclient.Conn
+}
+
+var _ Worker = (*HomeAssistantWorker)(nil)
+
+// NewHomeAssistantWorker creates a new HomeAssistantWorker.
+func NewHomeAssistantWorker(
+	logger *slog.Logger,
+	cfg *config.Config,
+	bridge *homeassistant.Bridge,
+	broker *mqttbroker.Broker,
+) *HomeAssistantWorker {
+	return &HomeAssistantWorker{
+		logger: logger.With(
+			slog.String("component", "worker"),
+			slog.String("worker", "homeassistant"),
+		),
+		cfg:    cfg,
+		bridge: bridge,
+		broker: broker,
+	}
+}
+
+// Name returns the worker type identifier.
+func (w *HomeAssistantWorker) Name() string { return "homeassistant" }
+
+// Prepare validates that the broker is configured when entities are registered.
+func (w *HomeAssistantWorker) Prepare() error {
+	if !w.bridge.Empty() && w.cfg.MQTT.Broker == "" && w.broker == nil {
+		return fmt.Errorf(
+			"%w: Home Assistant entities are registered but mqtt.broker is not set",
+			errs.ErrMQTTBrokerNotConfigured,
+		)
+	}
+
+	return nil
+}
+
+// Start connects to the MQTT broker, announces all entities after every (re)connect
+// and publishes their states until ctx is done. Without registered entities it is a no-op.
+func (w *HomeAssistantWorker) Start(ctx context.Context) error {
+	if w.bridge.Empty() {
+		w.logger.InfoContext(ctx, "no Home Assistant entities registered, skipping")
+
+		return nil
+	}
+
+	var (
+		conn mqttclient.Conn
+		err  error
+	)
+
+	callbacks := mqttclient.Callbacks{
+		OnConnect: func() {
+			if connectErr := w.bridge.Connected(ctx, conn); connectErr != nil {
+				w.logger.ErrorContext(ctx,
+					"failed to announce Home Assistant entities",
+					slog.Any("error", connectErr),
+				)
+			}
+		},
+		OnConnectionLost: func(err error) {
+			w.logger.WarnContext(ctx, "lost connection to MQTT broker", slog.Any("error", err))
+		},
+	}
+
+	if w.broker != nil {
+		conn, err = w.broker.Conn(callbacks)
+	} else {
+		conn, err = mqttclient.New(
+			w.cfg.MQTT,
+			"homeassistant",
+			w.logger,
+			callbacks,
+			w.bridge.Will(),
+		)
+	}
+
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	connected, err := conn.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to MQTT broker: %w", err)
+	}
+
+	if !connected {
+		w.logger.WarnContext(ctx, "MQTT broker not reachable yet, retrying in background")
+	}
+
+	w.conn = conn
+
+	w.bridge.Poll(ctx, conn)
+
+	return nil
+}
+
+// Stop marks all entities unavailable and disconnects from the MQTT broker.
+func (w *HomeAssistantWorker) Stop(ctx context.Context) error {
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.bridge.Offline(ctx, w.conn)
+
+	w.conn.Disconnect()
+	w.conn = nil
+
+	return err //nolint:wrapcheck
+}
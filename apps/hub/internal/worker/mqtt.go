@@ -2,53 +2,90 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"os"
 	"strings"
 
-	mqtt "github.com/eclipse/paho.mqtt.golang"
-
 	"github.com/abgeo/maroid/apps/hub/internal/config"
 	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttbroker"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttclient"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttdlq"
 	"github.com/abgeo/maroid/apps/hub/internal/registry"
-	"github.com/abgeo/maroid/libs/pluginapi"
 )
 
 // MQTTWorker manages the MQTT broker connection and dispatches
 // incoming messages to registered subscribers.
+// The subscription set is (re)subscribed on every connect, so subscriptions survive
+// reconnects even without a persistent broker session.
+// With the embedded broker, the worker runs the broker and connects to it in-process.
 type MQTTWorker struct {
 	logger   *slog.Logger
 	cfg      *config.Config
 	registry *registry.MQTTSubscriberRegistry
+	// deadLetters is nil when dead-lettering is disabled.
+	deadLetters *mqttdlq.Store
+	// broker is nil unless the embedded broker is enabled.
+	broker     *mqttbroker.Broker
+	brokerName string
+
+	connection    *mqttConnection
+	conn          mqttclient.Conn
+	subscriptions []mqttSubscription
+	dispatchers   []*mqttDispatcher
+}
 
-	client mqtt.Client
+type mqttSubscription struct {
+	mqttclient.Subscription
+
+	subscriberID   string
+	effectiveTopic string
 }
 
-var _ Worker = (*MQTTWorker)(nil)
+var _ DetailedWorker = (*MQTTWorker)(nil)
+
+// embeddedBrokerName is reported as the broker when connected to the embedded broker.
+const embeddedBrokerName = "embedded"
 
 // NewMQTTWorker creates a new MQTTWorker.
 func NewMQTTWorker(
 	logger *slog.Logger,
 	cfg *config.Config,
 	registry *registry.MQTTSubscriberRegistry,
+	deadLetters *mqttdlq.Store,
+	broker *mqttbroker.Broker,
 ) *MQTTWorker {
+	brokerName := cfg.MQTT.Broker
+	if broker != nil {
+		brokerName = embeddedBrokerName
+	}
+
 	return &MQTTWorker{
 		logger: logger.With(
 			slog.String("component", "worker"),
 			slog.String("worker", "mqtt"),
 		),
-		cfg:      cfg,
-		registry: registry,
+		cfg:         cfg,
+		registry:    registry,
+		deadLetters: deadLetters,
+		broker:      broker,
+		brokerName:  brokerName,
+		connection:  newMQTTConnection(brokerName),
 	}
 }
 
 // Name returns the worker type identifier.
 func (w *MQTTWorker) Name() string { return "mqtt" }
 
+// Details returns the broker connection status.
+func (w *MQTTWorker) Details() any {
+	return w.connection.snapshot()
+}
+
 // Prepare validates that the broker is configured when subscribers are registered.
 func (w *MQTTWorker) Prepare() error {
-	if len(w.registry.All()) > 0 && w.cfg.MQTT.Broker == "" {
+	if len(w.registry.All()) > 0 && w.cfg.MQTT.Broker == "" && w.broker == nil {
 		return fmt.Errorf(
 			"%w: subscribers are registered but mqtt.broker is not set",
 			errs.ErrMQTTBrokerNotConfigured,
@@ -59,127 +96,252 @@ func (w *MQTTWorker) Prepare() error {
 }
 
 // Start connects to the MQTT broker and subscribes all registered handlers.
-// It is a no-op if no subscribers are registered.
+// It returns an error if subscribing fails after any (re)connect, so that the supervisor
+// restarts the worker with a fresh connection. Without registered subscribers it only runs
+// the embedded broker, if enabled, and is a no-op otherwise.
 func (w *MQTTWorker) Start(ctx context.Context) error {
 	var err error
 
+	if w.broker != nil {
+		if err = w.broker.Start(); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		w.logger.InfoContext(ctx, "embedded MQTT broker started")
+	}
+
 	if len(w.registry.All()) == 0 {
 		w.logger.InfoContext(ctx, "no MQTT subscribers registered, skipping")
 
+		if w.broker != nil {
+			<-ctx.Done()
+		}
+
 		return nil
 	}
 
-	w.client, err = w.connect()
+	w.prepareSubscriptions()
+
+	subscribeErrs := make(chan error, 1)
+
+	w.conn, err = w.connect(ctx, subscribeErrs)
 	if err != nil {
-		return err
+		return errors.Join(err, w.drain(ctx), w.closeBroker())
 	}
 
-	w.logger.InfoContext(ctx, "connected to MQTT broker", slog.String("broker", w.cfg.MQTT.Broker))
-
-	if err = w.subscribe(ctx); err != nil {
-		return err
+	select {
+	case <-ctx.Done():
+		return nil
+	case err = <-subscribeErrs:
 	}
 
-	<-ctx.Done()
+	// Drop the connection so that a supervised restart starts from scratch.
+	w.conn.Disconnect()
+	w.conn = nil
+	w.connection.disconnected()
 
-	return nil
+	if drainErr := w.drain(ctx); drainErr != nil {
+		w.logger.ErrorContext(ctx, "failed to drain MQTT dispatchers", slog.Any("error", drainErr))
+	}
+
+	return errors.Join(err, w.closeBroker())
 }
 
-// Stop disconnects from the MQTT broker.
+// Stop disconnects from the MQTT broker and waits for in-flight messages to be handled.
+// The embedded broker is stopped last.
 func (w *MQTTWorker) Stop(ctx context.Context) error {
-	if w.client == nil {
+	var err error
+
+	if w.conn != nil {
+		w.logger.InfoContext(ctx, "disconnecting from MQTT broker")
+		w.conn.Disconnect()
+		w.connection.disconnected()
+
+		err = w.drain(ctx)
+	}
+
+	return errors.Join(err, w.closeBroker())
+}
+
+func (w *MQTTWorker) closeBroker() error {
+	if w.broker == nil {
 		return nil
 	}
 
-	w.logger.InfoContext(ctx, "disconnecting from MQTT broker")
-	w.client.Disconnect(w.cfg.MQTT.DisconnectQuiesce)
+	return w.broker.Close() //nolint:wrapcheck
+}
 
-	return nil
+// drain stops all subscriber dispatchers, waiting for queued messages until ctx is done.
+func (w *MQTTWorker) drain(ctx context.Context) error {
+	dispatchers := w.dispatchers
+	w.dispatchers = nil
+
+	errList := make([]error, 0, len(dispatchers))
+	for _, dispatcher := range dispatchers {
+		errList = append(errList, dispatcher.Drain(ctx))
+	}
+
+	return errors.Join(errList...)
 }
 
-func (w *MQTTWorker) connect() (mqtt.Client, error) {
+// prepareSubscriptions builds the subscription set and a dispatcher for every subscriber.
+func (w *MQTTWorker) prepareSubscriptions() {
+	subscribers := w.registry.All()
+
+	w.subscriptions = make([]mqttSubscription, 0, len(subscribers))
+	w.dispatchers = make([]*mqttDispatcher, 0, len(subscribers))
+
+	for effectiveTopic, sub := range subscribers {
+		// Derive the namespace prefix: effectiveTopic minus as many levels as the relative topic has.
+		// e.g. "dev/maroid/jasmine/+/+/measurement/+" → "dev/maroid/jasmine"
+		levels := strings.Split(effectiveTopic, "/")
+		relativeLevels := strings.Count(sub.Meta().Topic, "/") + 1
+		namespace := strings.Join(levels[:len(levels)-relativeLevels], "/")
+
+		dispatcher := newMQTTDispatcher(
+			w.logger,
+			w.cfg.MQTT.Dispatch,
+			effectiveTopic,
+			sub,
+			w.deadLetters,
+		)
+		w.dispatchers = append(w.dispatchers, dispatcher)
+
+		w.subscriptions = append(w.subscriptions, mqttSubscription{
+			Subscription: mqttclient.Subscription{
+				Topic:   w.buildSubscribeTopic(effectiveTopic),
+				QoS:     sub.Meta().QoS,
+				Handler: w.makeHandler(namespace, dispatcher),
+			},
+			subscriberID:   sub.Meta().ID,
+			effectiveTopic: effectiveTopic,
+		})
+	}
+}
+
+//nolint:ireturn
+func (w *MQTTWorker) connect(
+	ctx context.Context,
+	subscribeErrs chan<- error,
+) (mqttclient.Conn, error) {
 	cfg := w.cfg.MQTT
 
-	hostname, err := os.Hostname()
+	var (
+		conn mqttclient.Conn
+		err  error
+	)
+
+	callbacks := mqttclient.Callbacks{
+		OnConnect: func() {
+			w.onConnect(ctx, conn, subscribeErrs)
+		},
+		OnConnectionLost: func(err error) {
+			w.connection.lost(err)
+			w.logger.WarnContext(ctx, "lost connection to MQTT broker", slog.Any("error", err))
+		},
+		OnReconnecting: func() {
+			w.connection.reconnecting()
+			w.logger.InfoContext(ctx, "reconnecting to MQTT broker")
+		},
+	}
+
+	if w.broker != nil {
+		conn, err = w.broker.Conn(callbacks)
+	} else {
+		conn, err = mqttclient.New(cfg, "", w.logger, callbacks, nil)
+	}
+
 	if err != nil {
-		return nil, fmt.Errorf("getting hostname: %w", err)
+		return nil, err //nolint:wrapcheck
 	}
 
-	opts := mqtt.NewClientOptions().
-		SetAutoReconnect(true).
-		SetConnectRetry(true).
-		SetConnectTimeout(cfg.ConnectTimeout).
-		AddBroker(cfg.Broker).
-		SetClientID(fmt.Sprintf("%s@%s", cfg.ClientIDPrefix, hostname)).
-		SetUsername(cfg.User).
-		SetPassword(cfg.Password)
-	client := mqtt.NewClient(opts)
+	w.connection.connecting(len(w.subscriptions))
+
+	connected, err := conn.Connect(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
 
-	token := client.Connect()
-	if token.WaitTimeout(cfg.ConnectTimeout) && token.Error() != nil {
-		return nil, fmt.Errorf("connecting to MQTT broker %s: %w", cfg.Broker, token.Error())
+	if !connected {
+		w.logger.WarnContext(ctx,
+			"MQTT broker not reachable yet, retrying in background",
+			slog.String("broker", w.brokerName),
+		)
 	}
 
-	return client, nil
+	return conn, nil
 }
 
-func (w *MQTTWorker) subscribe(ctx context.Context) error {
-	for effectiveTopic, sub := range w.registry.All() {
-		// Derive the namespace prefix: effectiveTopic minus the relative topic suffix.
-		// e.g. "dev/maroid/jasmine/measurement/+/+" → "dev/maroid/jasmine"
-		namespace := strings.TrimSuffix(effectiveTopic, "/"+sub.Meta().Topic)
-		subscribeTopic := w.buildSubscribeTopic(effectiveTopic)
-
-		token := w.client.Subscribe(
-			subscribeTopic,
-			sub.Meta().QoS,
-			//nolint:contextcheck // paho MessageHandler signature provides no context parameter
-			w.makeHandler(namespace, sub),
-		)
-		if token.WaitTimeout(w.cfg.MQTT.ConnectTimeout) && token.Error() != nil {
-			return fmt.Errorf("subscribing to topic %s: %w", subscribeTopic, token.Error())
+// onConnect subscribes the subscription set after every (re)connect.
+// A subscribe failure is reported on subscribeErrs unless one is already pending.
+func (w *MQTTWorker) onConnect(
+	ctx context.Context,
+	conn mqttclient.Conn,
+	subscribeErrs chan<- error,
+) {
+	reconnect := w.connection.connected()
+
+	w.logger.InfoContext(ctx,
+		"connected to MQTT broker",
+		slog.String("broker", w.brokerName),
+		slog.Bool("reconnect", reconnect),
+	)
+
+	if err := w.subscribe(ctx, conn); err != nil {
+		w.logger.ErrorContext(ctx, "failed to subscribe to MQTT topics", slog.Any("error", err))
+
+		select {
+		case subscribeErrs <- err:
+		default:
+		}
+	}
+}
+
+func (w *MQTTWorker) subscribe(ctx context.Context, conn mqttclient.Conn) error {
+	for _, subscription := range w.subscriptions {
+		if err := conn.Subscribe(ctx, subscription.Subscription); err != nil {
+			return err //nolint:wrapcheck
 		}
 
-		w.logger.InfoContext(ctx,
+		w.logger.DebugContext(ctx,
 			"subscribed to MQTT topic",
-			slog.String("subscriber_id", sub.Meta().ID),
-			slog.String("effective_topic", effectiveTopic),
-			slog.String("subscribe_topic", subscribeTopic),
-			slog.Int("qos", int(sub.Meta().QoS)),
+			slog.String("subscriber_id", subscription.subscriberID),
+			slog.String("effective_topic", subscription.effectiveTopic),
+			slog.String("subscribe_topic", subscription.Topic),
+			slog.Int("qos", int(subscription.QoS)),
 		)
 	}
 
+	w.logger.InfoContext(ctx,
+		"subscribed to MQTT topics",
+		slog.Int("subscriptions", len(w.subscriptions)),
+	)
+
 	return nil
 }
 
 // buildSubscribeTopic wraps the effective topic in a shared subscription if configured.
+// The embedded broker serves a single hub, so its subscriptions are never shared.
 func (w *MQTTWorker) buildSubscribeTopic(effectiveTopic string) string {
-	if w.cfg.MQTT.SharedGroup == "" {
+	if w.cfg.MQTT.SharedGroup == "" || w.broker != nil {
 		return effectiveTopic
 	}
 
 	return fmt.Sprintf("$share/%s/%s", w.cfg.MQTT.SharedGroup, effectiveTopic)
 }
 
-// makeHandler returns a paho MessageHandler that strips the namespace prefix and
-// dispatches to the subscriber in a goroutine to avoid blocking the MQTT receive loop.
+// makeHandler returns a message handler that strips the namespace prefix and
+// queues the message on the subscriber dispatcher, keeping the MQTT receive loop free of handler work.
 func (w *MQTTWorker) makeHandler(
 	namespace string,
-	sub pluginapi.MQTTSubscriber,
-) mqtt.MessageHandler {
-	return func(_ mqtt.Client, msg mqtt.Message) {
-		go func() {
-			relativeTopic := strings.TrimPrefix(msg.Topic(), namespace+"/")
-
-			logger := w.logger.With(
-				slog.String("subscriber_id", sub.Meta().ID),
-				slog.String("topic", msg.Topic()),
-				slog.String("relative_topic", relativeTopic),
-			)
-
-			if err := sub.Handle(context.Background(), relativeTopic, msg.Payload()); err != nil {
-				logger.Error("mqtt subscriber handle error", slog.Any("error", err))
-			}
-		}()
+	dispatcher *mqttDispatcher,
+) mqttclient.MessageHandler {
+	return func(msg mqttclient.Message) {
+		dispatcher.Dispatch(mqttDelivery{
+			topic:         msg.Topic,
+			relativeTopic: strings.TrimPrefix(msg.Topic, namespace+"/"),
+			payload:       msg.Payload,
+			properties:    msg.Properties,
+		})
 	}
 }
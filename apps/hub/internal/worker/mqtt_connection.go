@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// MQTTConnectionState represents the state of the MQTT worker broker connection.
+type MQTTConnectionState string
+
+// MQTT connection states.
+const (
+	MQTTConnectionDisconnected MQTTConnectionState = "disconnected"
+	MQTTConnectionConnecting   MQTTConnectionState = "connecting"
+	MQTTConnectionConnected    MQTTConnectionState = "connected"
+	MQTTConnectionReconnecting MQTTConnectionState = "reconnecting"
+)
+
+// MQTTConnectionStatus describes the MQTT worker broker connection.
+// Reconnects counts the connections established after the first one, over the worker lifetime.
+type MQTTConnectionStatus struct {
+	State                MQTTConnectionState `json:"state"`
+	Broker               string              `json:"broker"`
+	Subscriptions        int                 `json:"subscriptions"`
+	Reconnects           int                 `json:"reconnects"`
+	LastConnectAt        *time.Time          `json:"last_connect_at,omitempty"`
+	LastConnectionLostAt *time.Time          `json:"last_connection_lost_at,omitempty"`
+	LastConnectionError  string              `json:"last_connection_error,omitempty"`
+}
+
+// mqttConnection tracks the broker connection through the paho lifecycle callbacks.
+type mqttConnection struct {
+	mu     sync.RWMutex
+	status MQTTConnectionStatus
+}
+
+func newMQTTConnection(broker string) *mqttConnection {
+	return &mqttConnection{
+		status: MQTTConnectionStatus{
+			State:  MQTTConnectionDisconnected,
+			Broker: broker,
+		},
+	}
+}
+
+func (c *mqttConnection) connecting(subscriptions int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.status.State = MQTTConnectionConnecting
+	c.status.Subscriptions = subscriptions
+}
+
+// connected records an established connection and reports whether it is a reconnect.
+func (c *mqttConnection) connected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	reconnect := c.status.LastConnectAt != nil
+
+	if reconnect {
+		c.status.Reconnects++
+	}
+
+	c.status.State = MQTTConnectionConnected
+	c.status.LastConnectAt = &now
+
+	return reconnect
+}
+
+func (c *mqttConnection) lost(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	c.status.State = MQTTConnectionReconnecting
+	c.status.LastConnectionLostAt = &now
+
+	if err != nil {
+		c.status.LastConnectionError = err.Error()
+	}
+}
+
+func (c *mqttConnection) reconnecting() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.status.State = MQTTConnectionReconnecting
+}
+
+func (c *mqttConnection) disconnected() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.status.State = MQTTConnectionDisconnected
+}
+
+func (c *mqttConnection) snapshot() MQTTConnectionStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.status
+}
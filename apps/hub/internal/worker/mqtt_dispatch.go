@@ -0,0 +1,259 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+	"github.com/abgeo/maroid/apps/hub/internal/model"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttdlq"
+	"github.com/abgeo/maroid/apps/hub/internal/mqttsubscriber"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+type mqttDelivery struct {
+	topic         string
+	relativeTopic string
+	payload       []byte
+	properties    pluginapi.MQTTProperties
+}
+
+// mqttDispatcher hands messages of a single subscriber to a bounded pool of handlers.
+// In ordered mode every handler owns a lane and messages are routed to lanes by topic,
+// so messages of the same topic are handled sequentially; otherwise all handlers share one queue.
+// Messages the subscriber fails to handle are persisted to the dead-letter store, if one is set.
+type mqttDispatcher struct {
+	logger       *slog.Logger
+	cfg          config.MQTTDispatch
+	subscription string
+	sub          pluginapi.MQTTSubscriber
+	deadLetters  *mqttdlq.Store
+
+	lanes []chan mqttDelivery
+
+	//nolint:containedctx // handler context outlives Start and is cancelled when draining times out
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	stopping chan struct{}
+	mu       sync.RWMutex
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+func newMQTTDispatcher(
+	logger *slog.Logger,
+	cfg config.MQTTDispatch,
+	subscription string,
+	sub pluginapi.MQTTSubscriber,
+	deadLetters *mqttdlq.Store,
+) *mqttDispatcher {
+	meta := sub.Meta()
+
+	if meta.Concurrency > 0 {
+		cfg.Concurrency = meta.Concurrency
+	}
+
+	cfg.Ordered = cfg.Ordered || meta.Ordered
+
+	laneCount := 1
+	if cfg.Ordered {
+		laneCount = cfg.Concurrency
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	dispatcher := &mqttDispatcher{
+		logger:       logger.With(slog.String("subscriber_id", meta.ID)),
+		cfg:          cfg,
+		subscription: subscription,
+		sub:          sub,
+		deadLetters:  deadLetters,
+		lanes:        make([]chan mqttDelivery, laneCount),
+		ctx:          ctx,
+		cancel:       cancel,
+		stopping:     make(chan struct{}),
+	}
+
+	for i := range dispatcher.lanes {
+		dispatcher.lanes[i] = make(chan mqttDelivery, cfg.QueueDepth)
+	}
+
+	for i := range cfg.Concurrency {
+		lane := dispatcher.lanes[i%laneCount]
+
+		dispatcher.wg.Go(func() {
+			for delivery := range lane {
+				dispatcher.handle(delivery)
+			}
+		})
+	}
+
+	return dispatcher
+}
+
+// Dispatch queues the message according to the overflow policy.
+// It blocks only with the block policy, and never after the dispatcher started draining.
+func (d *mqttDispatcher) Dispatch(delivery mqttDelivery) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		d.drop(delivery, "dispatcher is stopping")
+
+		return
+	}
+
+	lane := d.lanes[d.laneIndex(delivery.topic)]
+
+	switch d.cfg.OverflowPolicy {
+	case config.MQTTOverflowDropNewest:
+		select {
+		case lane <- delivery:
+		default:
+			d.drop(delivery, "queue is full")
+		}
+	case config.MQTTOverflowDropOldest:
+		for {
+			select {
+			case lane <- delivery:
+				return
+			default:
+			}
+
+			select {
+			case oldest := <-lane:
+				d.drop(oldest, "queue is full")
+			default:
+			}
+		}
+	default:
+		select {
+		case lane <- delivery:
+		case <-d.stopping:
+			d.drop(delivery, "dispatcher is stopping")
+		}
+	}
+}
+
+// Drain stops accepting messages and waits for queued ones to be handled.
+// Handlers still running when ctx is done are cancelled.
+func (d *mqttDispatcher) Drain(ctx context.Context) error {
+	close(d.stopping)
+
+	d.mu.Lock()
+	d.closed = true
+
+	for _, lane := range d.lanes {
+		close(lane)
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.cancel()
+
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+
+		return fmt.Errorf("draining MQTT subscriber %s: %w", d.sub.Meta().ID, ctx.Err())
+	}
+}
+
+func (d *mqttDispatcher) handle(delivery mqttDelivery) {
+	logger := d.logger.With(
+		slog.String("topic", delivery.topic),
+		slog.String("relative_topic", delivery.relativeTopic),
+	)
+
+	err := d.invoke(delivery)
+	if err == nil {
+		return
+	}
+
+	if errors.Is(err, errs.ErrMQTTMessageDecode) {
+		logger.Warn("mqtt message decode error", slog.Any("error", err))
+	} else {
+		logger.Error("mqtt subscriber handle error", slog.Any("error", err))
+	}
+
+	if d.deadLetters == nil {
+		return
+	}
+
+	// The message is persisted even if draining already cancelled the handler context.
+	ctx := context.WithoutCancel(d.ctx)
+
+	err = d.deadLetters.Record(ctx, &model.MQTTDeadLetter{
+		Subscription:  d.subscription,
+		SubscriberID:  d.sub.Meta().ID,
+		Topic:         delivery.topic,
+		RelativeTopic: delivery.relativeTopic,
+		Payload:       delivery.payload,
+		LastError:     err.Error(),
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to dead-letter mqtt message", slog.Any("error", err))
+	}
+}
+
+func (d *mqttDispatcher) invoke(delivery mqttDelivery) (err error) {
+	ctx := d.ctx
+
+	if d.cfg.HandlerTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, d.cfg.HandlerTimeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("%w: %v", errs.ErrMQTTSubscriberPanicked, rec)
+		}
+	}()
+
+	//nolint:wrapcheck
+	return mqttsubscriber.Deliver(
+		ctx,
+		d.sub,
+		delivery.relativeTopic,
+		delivery.payload,
+		delivery.properties,
+	)
+}
+
+func (d *mqttDispatcher) laneIndex(topic string) int {
+	if len(d.lanes) == 1 {
+		return 0
+	}
+
+	laneCount := uint32(len(d.lanes)) //nolint:gosec // lane count is a small positive int
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(topic))
+
+	return int(hash.Sum32() % laneCount)
+}
+
+func (d *mqttDispatcher) drop(delivery mqttDelivery, reason string) {
+	d.logger.Warn(
+		"dropping mqtt message",
+		slog.String("topic", delivery.topic),
+		slog.String("reason", reason),
+		slog.String("overflow_policy", d.cfg.OverflowPolicy),
+	)
+}
@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/outbox"
+)
+
+// OutboxWorker polls the notification outbox and delivers due notifications
+// with bounded concurrency.
+type OutboxWorker struct {
+	logger *slog.Logger
+	cfg    config.NotifierOutbox
+	outbox *outbox.Outbox
+
+	slots   chan struct{}
+	running sync.WaitGroup
+}
+
+var _ Worker = (*OutboxWorker)(nil)
+
+// NewOutboxWorker creates a new OutboxWorker.
+func NewOutboxWorker(
+	logger *slog.Logger,
+	cfg *config.Config,
+	notificationOutbox *outbox.Outbox,
+) *OutboxWorker {
+	return &OutboxWorker{
+		logger: logger.With(
+			slog.String("component", "worker"),
+			slog.String("worker", "outbox"),
+		),
+		cfg:    cfg.NotifierOutbox,
+		outbox: notificationOutbox,
+		slots:  make(chan struct{}, cfg.NotifierOutbox.Concurrency),
+	}
+}
+
+// Name returns the worker type identifier.
+func (w *OutboxWorker) Name() string { return "outbox" }
+
+// Prepare is a no-op; notifications are enqueued at runtime.
+func (w *OutboxWorker) Prepare() error {
+	return nil
+}
+
+// Start polls for due deliveries and blocks until the context is cancelled.
+func (w *OutboxWorker) Start(ctx context.Context) error {
+	w.logger.InfoContext(ctx, "notification outbox started",
+		slog.Duration("poll_interval", w.cfg.PollInterval),
+		slog.Int("concurrency", w.cfg.Concurrency),
+	)
+
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop waits for running deliveries to finish.
+func (w *OutboxWorker) Stop(ctx context.Context) error {
+	w.logger.InfoContext(ctx, "stopping notification outbox")
+
+	done := make(chan struct{})
+
+	go func() {
+		w.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.logger.InfoContext(ctx, "all notification deliveries have stopped")
+	case <-ctx.Done():
+		w.logger.WarnContext(ctx, "notification outbox stop timed out")
+	}
+
+	return nil
+}
+
+func (w *OutboxWorker) poll(ctx context.Context) {
+	free := cap(w.slots) - len(w.slots)
+	if free == 0 {
+		return
+	}
+
+	deliveries, err := w.outbox.ClaimDue(ctx, free)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.ErrorContext(ctx, "claiming due notification deliveries failed", slog.Any("error", err))
+		}
+
+		return
+	}
+
+	for _, delivery := range deliveries {
+		w.slots <- struct{}{}
+
+		w.running.Go(func() {
+			defer func() { <-w.slots }()
+
+			// Deliveries are not interrupted on shutdown; Stop waits for them instead.
+			w.outbox.Deliver(context.WithoutCancel(ctx), &delivery)
+		})
+	}
+}
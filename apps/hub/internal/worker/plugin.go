@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/abgeo/maroid/apps/hub/internal/registry"
+	"github.com/abgeo/maroid/libs/pluginapi"
+)
+
+// PluginWorker adapts a worker provided by a plugin, exposing it under its fully qualified name.
+type PluginWorker struct {
+	name   string
+	worker pluginapi.Worker
+}
+
+var _ Worker = (*PluginWorker)(nil)
+
+// NewPluginWorker creates a new PluginWorker from a registered plugin worker entry.
+func NewPluginWorker(entry registry.WorkerEntry) *PluginWorker {
+	return &PluginWorker{
+		name:   entry.Name,
+		worker: entry.Worker,
+	}
+}
+
+// Name returns the worker identifier in the "plugin:<plugin id>:<worker name>" form.
+func (w *PluginWorker) Name() string { return w.name }
+
+// Prepare delegates to the plugin worker.
+func (w *PluginWorker) Prepare() error {
+	return w.worker.Prepare() //nolint:wrapcheck
+}
+
+// Start delegates to the plugin worker.
+func (w *PluginWorker) Start(ctx context.Context) error {
+	return w.worker.Start(ctx) //nolint:wrapcheck
+}
+
+// Stop delegates to the plugin worker.
+func (w *PluginWorker) Stop(ctx context.Context) error {
+	return w.worker.Stop(ctx) //nolint:wrapcheck
+}
@@ -0,0 +1,117 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/queue"
+)
+
+// QueueWorker polls the task queue and runs due tasks with bounded concurrency.
+type QueueWorker struct {
+	logger *slog.Logger
+	cfg    config.Queue
+	queue  *queue.Queue
+
+	slots   chan struct{}
+	running sync.WaitGroup
+}
+
+var _ Worker = (*QueueWorker)(nil)
+
+// NewQueueWorker creates a new QueueWorker.
+func NewQueueWorker(
+	logger *slog.Logger,
+	cfg *config.Config,
+	taskQueue *queue.Queue,
+) *QueueWorker {
+	return &QueueWorker{
+		logger: logger.With(
+			slog.String("component", "worker"),
+			slog.String("worker", "queue"),
+		),
+		cfg:   cfg.Queue,
+		queue: taskQueue,
+		slots: make(chan struct{}, cfg.Queue.Concurrency),
+	}
+}
+
+// Name returns the worker type identifier.
+func (w *QueueWorker) Name() string { return "queue" }
+
+// Prepare is a no-op; tasks are enqueued at runtime.
+func (w *QueueWorker) Prepare() error {
+	return nil
+}
+
+// Start polls for due tasks and blocks until the context is cancelled.
+func (w *QueueWorker) Start(ctx context.Context) error {
+	w.logger.InfoContext(ctx, "task queue started",
+		slog.Duration("poll_interval", w.cfg.PollInterval),
+		slog.Int("concurrency", w.cfg.Concurrency),
+	)
+
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop waits for running tasks to finish.
+func (w *QueueWorker) Stop(ctx context.Context) error {
+	w.logger.InfoContext(ctx, "stopping task queue")
+
+	done := make(chan struct{})
+
+	go func() {
+		w.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.logger.InfoContext(ctx, "all queued tasks have stopped")
+	case <-ctx.Done():
+		w.logger.WarnContext(ctx, "task queue stop timed out")
+	}
+
+	return nil
+}
+
+func (w *QueueWorker) poll(ctx context.Context) {
+	free := cap(w.slots) - len(w.slots)
+	if free == 0 {
+		return
+	}
+
+	tasks, err := w.queue.ClaimDue(ctx, free)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.ErrorContext(ctx, "claiming due tasks failed", slog.Any("error", err))
+		}
+
+		return
+	}
+
+	for _, task := range tasks {
+		w.slots <- struct{}{}
+
+		w.running.Go(func() {
+			defer func() { <-w.slots }()
+
+			// Tasks are not interrupted on shutdown; Stop waits for them instead.
+			w.queue.Run(context.WithoutCancel(ctx), &task)
+		})
+	}
+}
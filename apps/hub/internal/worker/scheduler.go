@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/scheduler"
+)
+
+// SchedulerWorker polls the database for due tasks scheduled at runtime and runs them.
+type SchedulerWorker struct {
+	logger    *slog.Logger
+	cfg       config.Scheduler
+	scheduler *scheduler.Scheduler
+
+	running sync.WaitGroup
+}
+
+var _ Worker = (*SchedulerWorker)(nil)
+
+// NewSchedulerWorker creates a new SchedulerWorker.
+func NewSchedulerWorker(
+	logger *slog.Logger,
+	cfg *config.Config,
+	taskScheduler *scheduler.Scheduler,
+) *SchedulerWorker {
+	return &SchedulerWorker{
+		logger: logger.With(
+			slog.String("component", "worker"),
+			slog.String("worker", "scheduler"),
+		),
+		cfg:       cfg.Scheduler,
+		scheduler: taskScheduler,
+	}
+}
+
+// Name returns the worker type identifier.
+func (w *SchedulerWorker) Name() string { return "scheduler" }
+
+// Prepare is a no-op; tasks are scheduled at runtime.
+func (w *SchedulerWorker) Prepare() error {
+	return nil
+}
+
+// Start polls for due tasks and blocks until the context is cancelled.
+func (w *SchedulerWorker) Start(ctx context.Context) error {
+	w.logger.InfoContext(ctx, "task scheduler started",
+		slog.Duration("poll_interval", w.cfg.PollInterval),
+	)
+
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop waits for running tasks to finish.
+func (w *SchedulerWorker) Stop(ctx context.Context) error {
+	w.logger.InfoContext(ctx, "stopping task scheduler")
+
+	done := make(chan struct{})
+
+	go func() {
+		w.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.logger.InfoContext(ctx, "all scheduled tasks have stopped")
+	case <-ctx.Done():
+		w.logger.WarnContext(ctx, "task scheduler stop timed out")
+	}
+
+	return nil
+}
+
+func (w *SchedulerWorker) poll(ctx context.Context) {
+	tasks, err := w.scheduler.ClaimDue(ctx, w.cfg.BatchSize, w.cfg.LeaseDuration)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.ErrorContext(ctx, "claiming due tasks failed", slog.Any("error", err))
+		}
+
+		return
+	}
+
+	for _, task := range tasks {
+		w.running.Go(func() {
+			// Tasks are not interrupted on shutdown; Stop waits for them instead.
+			w.scheduler.Run(context.WithoutCancel(ctx), &task)
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abgeo/maroid/apps/hub/internal/backoff"
+	"github.com/abgeo/maroid/apps/hub/internal/config"
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+)
+
+// State represents the lifecycle state of a supervised worker.
+type State string
+
+// Supervised worker states.
+const (
+	StatePending    State = "pending"
+	StateRunning    State = "running"
+	StateBackingOff State = "backing_off"
+	StateFailed     State = "failed"
+	StateStopped    State = "stopped"
+)
+
+// Status describes the current state of a supervised worker.
+type Status struct {
+	Name          string     `json:"name"`
+	State         State      `json:"state"`
+	Restarts      int        `json:"restarts"`
+	LastError     string     `json:"last_error,omitempty"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	NextRestartAt *time.Time `json:"next_restart_at,omitempty"`
+	Details       any        `json:"details,omitempty"`
+}
+
+// Supervisor runs workers and restarts the ones that fail with exponential backoff.
+// A worker that exhausts its restart budget is marked as failed; it stops the remaining
+// workers only if the supervisor is configured to exit on failure.
+type Supervisor struct {
+	cfg             config.WorkerSupervisor
+	logger          *slog.Logger
+	workers         []Worker
+	shutdownTimeout time.Duration
+
+	mu       sync.RWMutex
+	statuses map[string]*Status
+}
+
+// NewSupervisor creates a new Supervisor for the given workers.
+func NewSupervisor(
+	cfg *config.Config,
+	logger *slog.Logger,
+	workers []Worker,
+	shutdownTimeout time.Duration,
+) *Supervisor {
+	statuses := make(map[string]*Status, len(workers))
+	for _, wrk := range workers {
+		statuses[wrk.Name()] = &Status{Name: wrk.Name(), State: StatePending}
+	}
+
+	return &Supervisor{
+		cfg: cfg.Worker.Supervisor,
+		logger: logger.With(
+			slog.String("component", "worker"),
+			slog.String("worker", "supervisor"),
+		),
+		workers:         workers,
+		shutdownTimeout: shutdownTimeout,
+		statuses:        statuses,
+	}
+}
+
+// Prepare prepares all supervised workers before any of them is started.
+func (s *Supervisor) Prepare() error {
+	for _, wrk := range s.workers {
+		s.logger.Info("preparing worker", slog.String("supervised_worker", wrk.Name()))
+
+		if err := wrk.Prepare(); err != nil {
+			return fmt.Errorf("preparing worker %s: %w", wrk.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// Statuses returns the current state of all supervised workers sorted by name.
+func (s *Supervisor) Statuses() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Status, 0, len(s.statuses))
+	for _, wrk := range s.workers {
+		status := *s.statuses[wrk.Name()]
+
+		if detailed, ok := wrk.(DetailedWorker); ok {
+			status.Details = detailed.Details()
+		}
+
+		result = append(result, status)
+	}
+
+	slices.SortFunc(result, func(a, b Status) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result
+}
+
+// Run starts all workers and blocks until ctx is cancelled, all workers have exited,
+// or a worker fails permanently while exit on failure is enabled.
+// Workers are then stopped within the shutdown timeout.
+func (s *Supervisor) Run(ctx context.Context) error {
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var running sync.WaitGroup
+
+	for _, wrk := range s.workers {
+		running.Go(func() {
+			err := s.supervise(runCtx, wrk)
+			if err != nil && s.cfg.ExitOnFailure {
+				cancel(err)
+			}
+		})
+	}
+
+	exited := make(chan struct{})
+
+	go func() {
+		running.Wait()
+		close(exited)
+	}()
+
+	select {
+	case <-runCtx.Done():
+		s.logger.InfoContext(ctx, "shutting down workers")
+	case <-exited:
+		s.logger.InfoContext(ctx, "all workers have exited")
+	}
+
+	s.stop(runCtx)
+	<-exited
+
+	if err := context.Cause(runCtx); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("worker error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Supervisor) supervise(ctx context.Context, wrk Worker) error {
+	name := wrk.Name()
+	logger := s.logger.With(slog.String("supervised_worker", name))
+
+	var restarts []time.Time
+
+	for {
+		startedAt := time.Now()
+		s.update(name, func(status *Status) {
+			status.State = StateRunning
+			status.StartedAt = &startedAt
+			status.NextRestartAt = nil
+		})
+
+		logger.InfoContext(ctx, "worker state changed", slog.String("state", string(StateRunning)))
+
+		err := start(ctx, wrk)
+		if ctx.Err() != nil || err == nil {
+			s.setState(name, StateStopped)
+			logger.InfoContext(ctx, "worker state changed", slog.String("state", string(StateStopped)))
+
+			return nil
+		}
+
+		now := time.Now()
+		restarts = slices.DeleteFunc(restarts, func(t time.Time) bool {
+			return now.Sub(t) > s.cfg.RestartWindow
+		})
+
+		if len(restarts) >= s.cfg.MaxRestarts {
+			s.update(name, func(status *Status) {
+				status.State = StateFailed
+				status.LastError = err.Error()
+			})
+
+			logger.ErrorContext(ctx, "worker state changed",
+				slog.String("state", string(StateFailed)),
+				slog.Int("restarts_in_window", len(restarts)),
+				slog.Bool("exit_on_failure", s.cfg.ExitOnFailure),
+				slog.Any("error", err),
+			)
+
+			return fmt.Errorf("%w: %s: %w", errs.ErrWorkerFailed, name, err)
+		}
+
+		restarts = append(restarts, now)
+		delay := s.backoff(len(restarts))
+		nextRestartAt := now.Add(delay)
+
+		s.update(name, func(status *Status) {
+			status.State = StateBackingOff
+			status.Restarts++
+			status.LastError = err.Error()
+			status.NextRestartAt = &nextRestartAt
+		})
+
+		logger.WarnContext(ctx, "worker state changed",
+			slog.String("state", string(StateBackingOff)),
+			slog.Duration("backoff", delay),
+			slog.Any("error", err),
+		)
+
+		select {
+		case <-ctx.Done():
+			s.setState(name, StateStopped)
+
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (s *Supervisor) stop(ctx context.Context) {
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.shutdownTimeout)
+	defer cancel()
+
+	for _, wrk := range s.workers {
+		if err := wrk.Stop(stopCtx); err != nil {
+			s.logger.ErrorContext(
+				stopCtx,
+				"worker stop failed",
+				slog.String("supervised_worker", wrk.Name()),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+func (s *Supervisor) backoff(restarts int) time.Duration {
+	return backoff.Exponential(s.cfg.InitialBackoff, s.cfg.MaxBackoff, restarts-1)
+}
+
+func (s *Supervisor) setState(name string, state State) {
+	s.update(name, func(status *Status) {
+		status.State = state
+		status.NextRestartAt = nil
+	})
+}
+
+func (s *Supervisor) update(name string, mutate func(status *Status)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mutate(s.statuses[name])
+}
+
+// start runs the worker and converts a panic into an error so that it can be restarted.
+func start(ctx context.Context, wrk Worker) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %v", errs.ErrWorkerPanicked, recovered)
+		}
+	}()
+
+	return wrk.Start(ctx) //nolint:wrapcheck
+}
@@ -1,7 +1,18 @@
 // Package worker provides the Worker interface and background worker implementations.
 package worker
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/abgeo/maroid/apps/hub/internal/domain/errs"
+)
+
+// AllWorkers is the worker name that selects every available worker.
+const AllWorkers = "all"
 
 // Worker represents a background worker that can be started and stopped.
 type Worker interface {
@@ -15,3 +26,42 @@ type Worker interface {
 	// Stop gracefully shuts down the worker within the provided context deadline.
 	Stop(ctx context.Context) error
 }
+
+// DetailedWorker is a Worker that reports worker-specific details, such as connection health,
+// alongside its supervised state.
+type DetailedWorker interface {
+	Worker
+	// Details returns a JSON-serializable snapshot of the worker details.
+	Details() any
+}
+
+// Select returns the workers with the given names, keeping the order of names.
+// Selecting AllWorkers returns every worker.
+func Select(workers []Worker, names []string) ([]Worker, error) {
+	if slices.Contains(names, AllWorkers) {
+		return workers, nil
+	}
+
+	result := make([]Worker, 0, len(names))
+	index := make(map[string]Worker, len(workers))
+
+	for _, wrk := range workers {
+		index[wrk.Name()] = wrk
+	}
+
+	for _, name := range names {
+		wrk, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf(
+				"%w: %q (available: %s)",
+				errs.ErrUnknownWorkerType,
+				name,
+				strings.Join(slices.Sorted(maps.Keys(index)), ", "),
+			)
+		}
+
+		result = append(result, wrk)
+	}
+
+	return result, nil
+}
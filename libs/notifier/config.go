@@ -1,5 +1,27 @@
 package notifier
 
+import "time"
+
+// DeliveryStrategy defines how a channel delivers a message through its transports.
+type DeliveryStrategy string
+
+// Delivery strategies. The same strategy applies to the fallback transports,
+// which are used once the primary transports do not satisfy it.
+const (
+	// StrategyAll sends through every transport and succeeds only if all of them succeed.
+	StrategyAll DeliveryStrategy = "all"
+	// StrategyAny sends through every transport and succeeds if at least one succeeds.
+	StrategyAny DeliveryStrategy = "any"
+	// StrategyFirst tries the transports in order and stops at the first success.
+	StrategyFirst DeliveryStrategy = "first"
+	// StrategyParallel sends through every transport concurrently, bounded by the
+	// channel timeout, and succeeds if at least one succeeds.
+	StrategyParallel DeliveryStrategy = "parallel"
+)
+
+// DefaultParallelTimeout bounds parallel deliveries of channels without a timeout.
+const DefaultParallelTimeout = 30 * time.Second
+
 // TransportConfig defines the configuration for a single notifier transport.
 type TransportConfig struct {
 	URL     string `validate:"required,url"`
@@ -12,6 +34,19 @@ type ChannelConfig struct {
 	Description string
 	Transports  []string `validate:"required,min=1"`
 	Fallback    []string
+	// Strategy defaults to StrategyAll.
+	Strategy DeliveryStrategy `validate:"omitempty,oneof=all any first parallel"`
+	// Timeout bounds the parallel strategy; it defaults to DefaultParallelTimeout.
+	Timeout time.Duration `validate:"gte=0"`
+}
+
+// EffectiveStrategy returns the channel strategy, falling back to StrategyAll.
+func (c ChannelConfig) EffectiveStrategy() DeliveryStrategy {
+	if c.Strategy == "" {
+		return StrategyAll
+	}
+
+	return c.Strategy
 }
 
 // Config holds the complete notification system configuration,
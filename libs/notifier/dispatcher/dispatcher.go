@@ -1,5 +1,6 @@
 // Package dispatcher provides functionality to route notification messages
-// to multiple channels and transports with automatic failover support.
+// to multiple channels and transports with configurable delivery strategies
+// and automatic failover support.
 package dispatcher
 
 import (
@@ -9,6 +10,7 @@ import (
 	"log/slog"
 	"maps"
 	"slices"
+	"time"
 
 	"github.com/abgeo/maroid/libs/notifier"
 	"github.com/abgeo/maroid/libs/notifier/registry"
@@ -19,8 +21,8 @@ var (
 	// ErrChannelNotFound is returned when attempting to send a message
 	// to a channel that does not exist in the configuration.
 	ErrChannelNotFound = errors.New("channel not found")
-	// ErrTransportsFailed is returned when all configured transports
-	// for a channel fail to deliver a message.
+	// ErrTransportsFailed is returned when the transports of a channel
+	// fail to deliver a message as its delivery strategy requires.
 	ErrTransportsFailed = errors.New("all transports failed")
 	// ErrTransportNotFound is returned when a channel references a
 	// transport that does not exist or is disabled.
@@ -68,59 +70,68 @@ func NewDispatcher(
 	}, nil
 }
 
-// Send delivers a message to the specified channel. It attempts all primary
-// transports first, then falls back to fallback transports if all primaries fail.
-// Returns an error only if all transports fail or if the channel doesn't exist.
+// Send delivers a message to the specified channel according to its delivery strategy.
+// It tries the primary transports first, then falls back to the fallback transports
+// if the primaries do not satisfy the strategy. The returned report lists the outcome
+// of every transport; an error is returned if neither group satisfies the strategy
+// or if the channel doesn't exist.
 func (d *ChannelDispatcher) Send(
 	ctx context.Context,
 	channelName string,
 	msg notifierapi.Message,
-) error {
+) (notifierapi.DeliveryReport, error) {
 	var (
 		primaryErr  error
 		fallbackErr error
 	)
 
+	report := notifierapi.DeliveryReport{Channel: channelName}
+
 	logger := d.logger.With(
 		slog.String("channel", channelName),
 	)
 
 	channel, exists := d.channels[channelName]
 	if !exists {
-		return fmt.Errorf("%w: %q", ErrChannelNotFound, channelName)
+		return report, fmt.Errorf("%w: %q", ErrChannelNotFound, channelName)
 	}
 
 	logger.Debug(
 		"sending message",
+		slog.String("strategy", string(channel.EffectiveStrategy())),
 		slog.Any("primary_transports", channel.Transports),
 		slog.Any("fallback_transports", channel.Fallback),
 	)
 
-	primaryErr = d.tryTransports(ctx, channel.Transports, msg)
+	report.Deliveries, primaryErr = d.deliver(ctx, channel, channel.Transports, false, msg)
 	if primaryErr == nil {
-		return nil
+		return report, nil
 	}
 
 	logger.Error(
-		"all primary transports failed",
+		"primary transports failed",
 		slog.Any("error", primaryErr),
 	)
 
 	if len(channel.Fallback) > 0 {
-		fallbackErr = d.tryTransports(ctx, channel.Fallback, msg)
+		var deliveries []notifierapi.TransportDelivery
+
+		deliveries, fallbackErr = d.deliver(ctx, channel, channel.Fallback, true, msg)
+		report.Deliveries = append(report.Deliveries, deliveries...)
+
 		if fallbackErr == nil {
-			return nil
+			return report, nil
 		}
 
 		logger.Error(
-			"all fallback transports failed",
+			"fallback transports failed",
 			slog.Any("error", fallbackErr),
 		)
 	}
 
-	d.logger.Error("all transports failed")
+	logger.Error("all transports failed")
 
-	return fmt.Errorf("%w for channel %q: %w",
+	return report, fmt.Errorf("%w for channel %q: %w",
 		ErrTransportsFailed,
 		channelName,
 		errors.Join(primaryErr, fallbackErr),
@@ -132,6 +143,27 @@ func (d *ChannelDispatcher) Channels() []string {
 	return slices.Sorted(maps.Keys(d.channels))
 }
 
+// Channel returns the configuration of the named channel.
+func (d *ChannelDispatcher) Channel(channelName string) (notifier.ChannelConfig, bool) {
+	channel, exists := d.channels[channelName]
+
+	return channel, exists
+}
+
+// SendVia delivers a message through a single named transport, without failover.
+func (d *ChannelDispatcher) SendVia(
+	ctx context.Context,
+	transportName string,
+	msg notifierapi.Message,
+) error {
+	transport, exists := d.transports[transportName]
+	if !exists {
+		return fmt.Errorf("%w: %q", ErrTransportNotFound, transportName)
+	}
+
+	return transport.Send(ctx, msg) //nolint:wrapcheck
+}
+
 func buildTransports(
 	configs map[string]notifier.TransportConfig,
 	reg registry.Registry,
@@ -180,36 +212,195 @@ func validateChannels(
 	return nil
 }
 
-func (d *ChannelDispatcher) tryTransports(
+// deliver sends a message through the named transports according to the channel strategy.
+// It returns the deliveries along with an error if they do not satisfy the strategy.
+func (d *ChannelDispatcher) deliver(
 	ctx context.Context,
+	channel notifier.ChannelConfig,
 	names []string,
+	fallback bool,
 	msg notifierapi.Message,
-) error {
+) ([]notifierapi.TransportDelivery, error) {
 	if len(names) == 0 {
-		return ErrNoTransports
+		return nil, ErrNoTransports
+	}
+
+	var deliveries []notifierapi.TransportDelivery
+
+	strategy := channel.EffectiveStrategy()
+
+	switch strategy {
+	case notifier.StrategyFirst:
+		deliveries = d.sendFirst(ctx, names, fallback, msg)
+	case notifier.StrategyParallel:
+		timeout := channel.Timeout
+		if timeout == 0 {
+			timeout = notifier.DefaultParallelTimeout
+		}
+
+		deliveries = d.sendParallel(ctx, names, fallback, timeout, msg)
+	case notifier.StrategyAll, notifier.StrategyAny:
+		deliveries = d.sendEach(ctx, names, fallback, msg)
 	}
 
 	var errs []error
 
+	for _, delivery := range deliveries {
+		if delivery.Status == notifierapi.DeliveryFailed {
+			errs = append(errs, fmt.Errorf("%s: %w", delivery.Transport, delivery.Err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return deliveries, nil
+	}
+
+	if strategy != notifier.StrategyAll && slices.ContainsFunc(deliveries, delivered) {
+		return deliveries, nil
+	}
+
+	return deliveries, errors.Join(errs...)
+}
+
+// sendEach sends a message through every transport in order.
+func (d *ChannelDispatcher) sendEach(
+	ctx context.Context,
+	names []string,
+	fallback bool,
+	msg notifierapi.Message,
+) []notifierapi.TransportDelivery {
+	deliveries := make([]notifierapi.TransportDelivery, 0, len(names))
+
+	for _, name := range names {
+		deliveries = append(deliveries, d.sendOne(ctx, name, fallback, msg))
+	}
+
+	return deliveries
+}
+
+// sendFirst tries the transports in order and skips the rest after the first success.
+func (d *ChannelDispatcher) sendFirst(
+	ctx context.Context,
+	names []string,
+	fallback bool,
+	msg notifierapi.Message,
+) []notifierapi.TransportDelivery {
+	deliveries := make([]notifierapi.TransportDelivery, 0, len(names))
+
 	for _, name := range names {
-		transport, ok := d.transports[name]
-		if !ok {
-			d.logger.Warn("unknown transport reference", slog.String("transport", name))
+		if slices.ContainsFunc(deliveries, delivered) {
+			deliveries = append(deliveries, notifierapi.TransportDelivery{
+				Transport: name,
+				Fallback:  fallback,
+				Status:    notifierapi.DeliverySkipped,
+			})
 
 			continue
 		}
 
-		d.logger.Debug("sending via transport", slog.String("transport", name))
+		deliveries = append(deliveries, d.sendOne(ctx, name, fallback, msg))
+	}
+
+	return deliveries
+}
+
+// sendParallel sends a message through every transport concurrently. Transports
+// that do not finish within the timeout are reported as failed without waiting for them.
+func (d *ChannelDispatcher) sendParallel(
+	ctx context.Context,
+	names []string,
+	fallback bool,
+	timeout time.Duration,
+	msg notifierapi.Message,
+) []notifierapi.TransportDelivery {
+	type result struct {
+		index    int
+		delivery notifierapi.TransportDelivery
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Buffered so that transports finishing after the timeout do not block.
+	results := make(chan result, len(names))
+
+	for index, name := range names {
+		go func() {
+			results <- result{index: index, delivery: d.sendOne(ctx, name, fallback, msg)}
+		}()
+	}
+
+	deliveries := make([]notifierapi.TransportDelivery, len(names))
+	done := make([]bool, len(names))
+
+	for range names {
+		select {
+		case res := <-results:
+			deliveries[res.index] = res.delivery
+			done[res.index] = true
+		case <-ctx.Done():
+			for index, name := range names {
+				if !done[index] {
+					deliveries[index] = notifierapi.TransportDelivery{
+						Transport: name,
+						Fallback:  fallback,
+						Status:    notifierapi.DeliveryFailed,
+						Err:       ctx.Err(),
+						Duration:  timeout,
+					}
+				}
+			}
 
-		if err := transport.Send(ctx, msg); err != nil {
-			d.logger.Error(
-				"transport failed",
-				slog.String("transport", name),
-				slog.Any("error", err),
-			)
-			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			return deliveries
 		}
 	}
 
-	return errors.Join(errs...)
+	return deliveries
+}
+
+// sendOne sends a message through a single transport and reports the outcome.
+func (d *ChannelDispatcher) sendOne(
+	ctx context.Context,
+	name string,
+	fallback bool,
+	msg notifierapi.Message,
+) notifierapi.TransportDelivery {
+	delivery := notifierapi.TransportDelivery{
+		Transport: name,
+		Fallback:  fallback,
+		Status:    notifierapi.DeliveryDelivered,
+	}
+
+	transport, ok := d.transports[name]
+	if !ok {
+		d.logger.Warn("unknown transport reference", slog.String("transport", name))
+
+		delivery.Status = notifierapi.DeliveryFailed
+		delivery.Err = ErrTransportNotFound
+
+		return delivery
+	}
+
+	d.logger.Debug("sending via transport", slog.String("transport", name))
+
+	start := time.Now()
+	err := transport.Send(ctx, msg)
+	delivery.Duration = time.Since(start)
+
+	if err != nil {
+		d.logger.Error(
+			"transport failed",
+			slog.String("transport", name),
+			slog.Any("error", err),
+		)
+
+		delivery.Status = notifierapi.DeliveryFailed
+		delivery.Err = err
+	}
+
+	return delivery
+}
+
+func delivered(delivery notifierapi.TransportDelivery) bool {
+	return delivery.Status == notifierapi.DeliveryDelivered
 }
@@ -5,6 +5,7 @@ go 1.26.0
 require (
 	github.com/abgeo/maroid/libs/notifierapi v0.0.0-20260228143744-1f0e855d780e
 	github.com/mymmrac/telego v1.5.0
+	golang.org/x/net v0.51.0
 )
 
 require (
@@ -21,5 +22,5 @@ require (
 	github.com/valyala/fasthttp v1.69.0 // indirect
 	github.com/valyala/fastjson v1.6.7 // indirect
 	golang.org/x/arch v0.23.0 // indirect
-	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/sys v0.41.0 // indirect
 )
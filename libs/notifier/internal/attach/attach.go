@@ -0,0 +1,21 @@
+// Package attach prepares notifier message attachments for the transports.
+package attach
+
+import (
+	"net/http"
+
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+// Normalize fills in the MIME type and filename of an attachment that lacks them.
+func Normalize(attachment notifierapi.Attachment) notifierapi.Attachment {
+	if attachment.MIMEType == "" {
+		attachment.MIMEType = http.DetectContentType(attachment.Content)
+	}
+
+	if attachment.Filename == "" {
+		attachment.Filename = "attachment"
+	}
+
+	return attachment
+}
@@ -0,0 +1,226 @@
+// Package markup renders notifier message titles and bodies in the native markup of a transport.
+// Rich bodies are rendered directly; legacy bodies are Telegram-flavoured HTML and are converted.
+package markup
+
+import (
+	"html"
+	"io"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+// Format is the native markup of a transport.
+type Format int
+
+// Supported formats.
+const (
+	FormatPlain Format = iota
+	FormatHTML
+	FormatMarkdown
+	FormatSlack
+)
+
+// style describes how a target format renders the supported HTML tags.
+type style struct {
+	// markers maps a tag name to its opening and closing markers.
+	markers map[string][2]string
+	// literal lists the tags whose content is not escaped.
+	literal map[string]bool
+	escape  func(text string) string
+	link    func(text string, href string) string
+}
+
+var (
+	plainStyle = style{
+		markers: map[string][2]string{},
+		escape:  func(text string) string { return text },
+		link: func(text string, href string) string {
+			if href == "" || text == href {
+				return text
+			}
+
+			return text + " (" + href + ")"
+		},
+	}
+
+	markdownStyle = style{
+		markers: map[string][2]string{
+			"b":          {"**", "**"},
+			"strong":     {"**", "**"},
+			"i":          {"*", "*"},
+			"em":         {"*", "*"},
+			"u":          {"__", "__"},
+			"ins":        {"__", "__"},
+			"s":          {"~~", "~~"},
+			"strike":     {"~~", "~~"},
+			"del":        {"~~", "~~"},
+			"code":       {"`", "`"},
+			"pre":        {"```\n", "\n```"},
+			"tg-spoiler": {"||", "||"},
+		},
+		literal: map[string]bool{"code": true, "pre": true},
+		escape: strings.NewReplacer(
+			`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, ">", `\>`,
+		).Replace,
+		link: func(text string, href string) string {
+			if href == "" {
+				return text
+			}
+
+			return "[" + text + "](" + href + ")"
+		},
+	}
+
+	slackStyle = style{
+		markers: map[string][2]string{
+			"b":      {"*", "*"},
+			"strong": {"*", "*"},
+			"i":      {"_", "_"},
+			"em":     {"_", "_"},
+			"s":      {"~", "~"},
+			"strike": {"~", "~"},
+			"del":    {"~", "~"},
+			"code":   {"`", "`"},
+			"pre":    {"```\n", "\n```"},
+		},
+		escape: strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace,
+		link: func(text string, href string) string {
+			if href == "" {
+				return text
+			}
+
+			return "<" + href + "|" + text + ">"
+		},
+	}
+)
+
+// Title returns the plain-text title of msg escaped for the given format.
+func Title(msg notifierapi.Message, format Format) string {
+	return render(notifierapi.RichText{notifierapi.Text(msg.Title)}, format)
+}
+
+// Body returns the body of msg in the given format. HTML keeps line breaks as newlines,
+// as Telegram expects; use LineBreaks for HTML documents.
+func Body(msg notifierapi.Message, format Format) string {
+	if len(msg.RichBody) > 0 {
+		return render(msg.RichBody, format)
+	}
+
+	switch format {
+	case FormatHTML:
+		return msg.Body
+	case FormatMarkdown:
+		return convert(msg.Body, markdownStyle)
+	case FormatSlack:
+		return convert(msg.Body, slackStyle)
+	case FormatPlain:
+	}
+
+	return convert(msg.Body, plainStyle)
+}
+
+// LineBreaks turns the newlines of an HTML fragment into line breaks.
+func LineBreaks(text string) string {
+	return strings.ReplaceAll(text, "\n", "<br>\n")
+}
+
+func render(text notifierapi.RichText, format Format) string {
+	switch format {
+	case FormatHTML:
+		return text.HTML()
+	case FormatMarkdown:
+		return text.Markdown()
+	case FormatSlack:
+		return text.Slack()
+	case FormatPlain:
+	}
+
+	return text.Plain()
+}
+
+// convert walks the legacy HTML tokens of text and renders them in the given style.
+// Unknown tags are dropped while their content is kept.
+func convert(text string, target style) string {
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(text))
+
+	// Link texts are collected separately, since most formats render them after the target.
+	builders := []*strings.Builder{{}}
+	hrefs := []string{}
+	literalDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == nethtml.ErrorToken {
+			if tokenizer.Err() != io.EOF {
+				return html.UnescapeString(text)
+			}
+
+			break
+		}
+
+		token := tokenizer.Token()
+		current := builders[len(builders)-1]
+
+		switch tokenType {
+		case nethtml.TextToken:
+			if literalDepth > 0 {
+				current.WriteString(token.Data)
+			} else {
+				current.WriteString(target.escape(token.Data))
+			}
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			switch {
+			case token.Data == "br":
+				current.WriteString("\n")
+			case token.Data == "a" && tokenType == nethtml.StartTagToken:
+				builders = append(builders, &strings.Builder{})
+				hrefs = append(hrefs, attribute(token, "href"))
+			default:
+				current.WriteString(target.markers[token.Data][0])
+
+				if target.literal[token.Data] && tokenType == nethtml.StartTagToken {
+					literalDepth++
+				}
+			}
+		case nethtml.EndTagToken:
+			if token.Data == "a" && len(builders) > 1 {
+				builders = builders[:len(builders)-1]
+				href := hrefs[len(hrefs)-1]
+				hrefs = hrefs[:len(hrefs)-1]
+
+				builders[len(builders)-1].WriteString(target.link(current.String(), href))
+
+				continue
+			}
+
+			current.WriteString(target.markers[token.Data][1])
+
+			if target.literal[token.Data] && literalDepth > 0 {
+				literalDepth--
+			}
+		case nethtml.ErrorToken, nethtml.CommentToken, nethtml.DoctypeToken:
+		}
+	}
+
+	// Close links left open by malformed input.
+	for len(builders) > 1 {
+		inner := builders[len(builders)-1].String()
+		builders = builders[:len(builders)-1]
+		builders[len(builders)-1].WriteString(inner)
+	}
+
+	return builders[0].String()
+}
+
+func attribute(token nethtml.Token, key string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
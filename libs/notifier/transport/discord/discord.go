@@ -0,0 +1,279 @@
+// Package discord implements a notifier transport for sending messages
+// through a Discord channel webhook.
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/abgeo/maroid/libs/notifier/internal/attach"
+	"github.com/abgeo/maroid/libs/notifier/internal/markup"
+	"github.com/abgeo/maroid/libs/notifier/registry"
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+const (
+	queryParamThread   = "x-thread"
+	queryParamUsername = "x-username"
+	queryParamAPIURL   = "x-api-url"
+
+	defaultAPIURL = "https://discord.com/api"
+
+	requestTimeout = 30 * time.Second
+	errorBodyLimit = 512
+	// maxContentLength and maxFiles are the Discord limits for a single webhook message.
+	maxContentLength = 2000
+	maxFiles         = 10
+)
+
+var (
+	// ErrMissingCredentials is returned when the URL is missing the webhook token or ID.
+	ErrMissingCredentials = errors.New("discord: missing webhook token or id in URL")
+	// ErrUnexpectedStatus is returned when Discord responds with a non-2xx status.
+	ErrUnexpectedStatus = errors.New("discord: unexpected response status")
+)
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// Config holds the configuration for the Discord notifier.
+type Config struct {
+	APIURL    string
+	WebhookID string
+	Token     string
+	ThreadID  string
+	Username  string
+}
+
+// Notifier implements the notifierapi.Transport interface for Discord.
+type Notifier struct {
+	config *Config
+	client *http.Client
+}
+
+type webhookPayload struct {
+	Content     string              `json:"content,omitempty"`
+	Username    string              `json:"username,omitempty"`
+	Attachments []webhookAttachment `json:"attachments,omitempty"`
+}
+
+type webhookAttachment struct {
+	ID       int    `json:"id"`
+	Filename string `json:"filename"`
+}
+
+var _ notifierapi.Transport = (*Notifier)(nil)
+
+// New creates a new Discord notifier from a URL configuration.
+// URL format: discord://TOKEN@WEBHOOK_ID?x-thread=THREAD_ID&x-username=NAME
+// TOKEN and WEBHOOK_ID are the last two segments of the webhook URL.
+func New(rawURL *url.URL) (notifierapi.Transport, error) {
+	cfg, err := parseConfiguration(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		config: cfg,
+		client: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Register registers the Discord notifier with the given registry.
+func Register(reg registry.Registry) error {
+	if err := reg.Register("discord", New); err != nil {
+		return fmt.Errorf("registering discord notifier: %w", err)
+	}
+
+	return nil
+}
+
+// Send posts the message as Markdown. Attachments are uploaded with it,
+// split into further messages when there are more than Discord allows in one.
+func (n *Notifier) Send(ctx context.Context, msg notifierapi.Message) error {
+	payload := webhookPayload{
+		Content:  truncate(formatMessageText(msg), maxContentLength),
+		Username: n.config.Username,
+	}
+
+	if len(msg.Attachments) == 0 {
+		return n.sendJSON(ctx, payload)
+	}
+
+	for start := 0; start < len(msg.Attachments); start += maxFiles {
+		batch := msg.Attachments[start:min(start+maxFiles, len(msg.Attachments))]
+
+		if err := n.sendFiles(ctx, payload, batch); err != nil {
+			return err
+		}
+
+		// Only the first message carries the text.
+		payload.Content = ""
+	}
+
+	return nil
+}
+
+func parseConfiguration(rawURL *url.URL) (*Config, error) {
+	token := rawURL.User.String()
+	webhookID := rawURL.Host
+
+	if token == "" || webhookID == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	apiURL := rawURL.Query().Get(queryParamAPIURL)
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+
+	return &Config{
+		APIURL:    strings.TrimSuffix(apiURL, "/"),
+		WebhookID: webhookID,
+		Token:     token,
+		ThreadID:  rawURL.Query().Get(queryParamThread),
+		Username:  rawURL.Query().Get(queryParamUsername),
+	}, nil
+}
+
+func (n *Notifier) sendJSON(ctx context.Context, payload webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding message: %w", err)
+	}
+
+	if err = n.post(ctx, "application/json", body); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
+func (n *Notifier) sendFiles(
+	ctx context.Context,
+	payload webhookPayload,
+	attachments []notifierapi.Attachment,
+) error {
+	var buf bytes.Buffer
+
+	writer := multipart.NewWriter(&buf)
+
+	payload.Attachments = make([]webhookAttachment, 0, len(attachments))
+	for i, attachment := range attachments {
+		payload.Attachments = append(payload.Attachments, webhookAttachment{
+			ID:       i,
+			Filename: attach.Normalize(attachment).Filename,
+		})
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding message: %w", err)
+	}
+
+	if err = writer.WriteField("payload_json", string(payloadJSON)); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+
+	for i, attachment := range attachments {
+		attachment = attach.Normalize(attachment)
+
+		part, partErr := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Disposition": {fmt.Sprintf(
+				`form-data; name="files[%d]"; filename="%s"`,
+				i,
+				quoteEscaper.Replace(attachment.Filename),
+			)},
+			"Content-Type": {attachment.MIMEType},
+		})
+		if partErr != nil {
+			return fmt.Errorf("creating attachment %q: %w", attachment.Filename, partErr)
+		}
+
+		if _, err = part.Write(attachment.Content); err != nil {
+			return fmt.Errorf("writing attachment %q: %w", attachment.Filename, err)
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("closing multipart body: %w", err)
+	}
+
+	if err = n.post(ctx, writer.FormDataContentType(), buf.Bytes()); err != nil {
+		return fmt.Errorf("sending attachments: %w", err)
+	}
+
+	return nil
+}
+
+func (n *Notifier) post(ctx context.Context, contentType string, body []byte) error {
+	query := url.Values{"wait": {"true"}}
+	if n.config.ThreadID != "" {
+		query.Set("thread_id", n.config.ThreadID)
+	}
+
+	endpoint := fmt.Sprintf(
+		"%s/webhooks/%s/%s?%s",
+		n.config.APIURL,
+		url.PathEscape(n.config.WebhookID),
+		url.PathEscape(n.config.Token),
+		query.Encode(),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+
+		return fmt.Errorf("%w: %s: %s", ErrUnexpectedStatus, resp.Status, bytes.TrimSpace(snippet))
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// truncate shortens text to at most limit runes, ending it with an ellipsis when cut.
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+
+	return string(runes[:limit-1]) + "…"
+}
+
+func formatMessageText(msg notifierapi.Message) string {
+	var parts []string
+
+	if msg.Title != "" {
+		parts = append(parts, "**"+markup.Title(msg, markup.FormatMarkdown)+"**")
+	}
+
+	if body := markup.Body(msg, markup.FormatMarkdown); body != "" {
+		parts = append(parts, body)
+	}
+
+	return strings.Join(parts, "\n\n")
+}
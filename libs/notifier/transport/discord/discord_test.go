@@ -0,0 +1,242 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+const (
+	testWebhookID = "123456"
+	testToken     = "webhook-token"
+)
+
+// stubFile is a file uploaded to the stub webhook.
+type stubFile struct {
+	field       string
+	filename    string
+	contentType string
+	content     string
+}
+
+// stubRequest is a request received by the stub webhook.
+type stubRequest struct {
+	method  string
+	path    string
+	query   url.Values
+	payload webhookPayload
+	files   []stubFile
+}
+
+// stubWebhook records requests and answers them with status.
+type stubWebhook struct {
+	t        *testing.T
+	mu       sync.Mutex
+	requests []stubRequest
+	status   int
+}
+
+func (s *stubWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := stubRequest{
+		method: r.Method,
+		path:   r.URL.Path,
+		query:  r.URL.Query(),
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "application/json":
+		if err := json.NewDecoder(r.Body).Decode(&request.payload); err != nil {
+			s.t.Errorf("decoding JSON body: %v", err)
+		}
+	case "multipart/form-data":
+		request.payload, request.files = s.readMultipart(r.Body, params["boundary"])
+	default:
+		s.t.Errorf("unexpected content type %q", mediaType)
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	s.mu.Unlock()
+
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(`{"message":"Unknown Webhook"}`))
+
+		return
+	}
+
+	_, _ = w.Write([]byte(`{"id":"1"}`))
+}
+
+func (s *stubWebhook) readMultipart(body io.Reader, boundary string) (webhookPayload, []stubFile) {
+	var (
+		payload webhookPayload
+		files   []stubFile
+	)
+
+	reader := multipart.NewReader(body, boundary)
+
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return payload, files
+		}
+
+		content, _ := io.ReadAll(part)
+
+		if part.FormName() == "payload_json" {
+			if err = json.Unmarshal(content, &payload); err != nil {
+				s.t.Errorf("decoding payload_json: %v", err)
+			}
+
+			continue
+		}
+
+		files = append(files, stubFile{
+			field:       part.FormName(),
+			filename:    part.FileName(),
+			contentType: part.Header.Get("Content-Type"),
+			content:     string(content),
+		})
+	}
+}
+
+func newTestTransport(t *testing.T, stub *stubWebhook, query string) notifierapi.Transport {
+	t.Helper()
+
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	parsed, err := url.Parse(
+		"discord://" + testToken + "@" + testWebhookID +
+			"?x-api-url=" + url.QueryEscape(server.URL) + query,
+	)
+	if err != nil {
+		t.Fatalf("parsing URL: %v", err)
+	}
+
+	transport, err := New(parsed)
+	if err != nil {
+		t.Fatalf("creating transport: %v", err)
+	}
+
+	return transport
+}
+
+func TestSendPostsMarkdownMessage(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubWebhook{t: t}
+	transport := newTestTransport(t, stub, "&x-thread=42&x-username=Maroid")
+
+	err := transport.Send(context.Background(), notifierapi.Message{
+		Title: "Bill *ready*",
+		RichBody: notifierapi.RichText{
+			notifierapi.Bold("Amount"),
+			notifierapi.Text(": 10 "),
+			notifierapi.Link("details", "https://example.com/bill"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("sending: %v", err)
+	}
+
+	if len(stub.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(stub.requests))
+	}
+
+	request := stub.requests[0]
+	assertRequest(t, request)
+
+	if request.query.Get("thread_id") != "42" {
+		t.Errorf("unexpected thread_id %q", request.query.Get("thread_id"))
+	}
+
+	want := "**Bill \\*ready\\***\n\n**Amount**: 10 [details](https://example.com/bill)"
+	if request.payload.Content != want {
+		t.Errorf("unexpected content %q, want %q", request.payload.Content, want)
+	}
+
+	if request.payload.Username != "Maroid" {
+		t.Errorf("unexpected username %q", request.payload.Username)
+	}
+}
+
+func TestSendUploadsAttachments(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubWebhook{t: t}
+	transport := newTestTransport(t, stub, "")
+
+	err := transport.Send(context.Background(), notifierapi.Message{
+		Title: "Bill",
+		Attachments: []notifierapi.Attachment{
+			{Filename: "bill.pdf", Content: []byte("%PDF-1.4"), MIMEType: "application/pdf"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("sending: %v", err)
+	}
+
+	if len(stub.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(stub.requests))
+	}
+
+	request := stub.requests[0]
+	assertRequest(t, request)
+
+	if request.payload.Content != "**Bill**" {
+		t.Errorf("unexpected content %q", request.payload.Content)
+	}
+
+	if len(request.payload.Attachments) != 1 || request.payload.Attachments[0].Filename != "bill.pdf" {
+		t.Errorf("unexpected payload attachments %v", request.payload.Attachments)
+	}
+
+	want := stubFile{
+		field:       "files[0]",
+		filename:    "bill.pdf",
+		contentType: "application/pdf",
+		content:     "%PDF-1.4",
+	}
+	if len(request.files) != 1 || request.files[0] != want {
+		t.Errorf("unexpected files %v, want %v", request.files, want)
+	}
+}
+
+func TestSendReturnsErrorOnUnexpectedStatus(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubWebhook{t: t, status: http.StatusNotFound}
+	transport := newTestTransport(t, stub, "")
+
+	err := transport.Send(context.Background(), notifierapi.Message{Title: "Test"})
+	if !errors.Is(err, ErrUnexpectedStatus) {
+		t.Fatalf("expected %v, got %v", ErrUnexpectedStatus, err)
+	}
+}
+
+// assertRequest checks that the request targets the webhook, which is authenticated by its token.
+func assertRequest(t *testing.T, request stubRequest) {
+	t.Helper()
+
+	if request.method != http.MethodPost || request.path != "/webhooks/"+testWebhookID+"/"+testToken {
+		t.Errorf("unexpected request %s %s", request.method, request.path)
+	}
+
+	if request.query.Get("wait") != "true" {
+		t.Errorf("unexpected query %q", request.query.Encode())
+	}
+}
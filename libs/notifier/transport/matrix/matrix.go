@@ -0,0 +1,260 @@
+// Package matrix implements a notifier transport for sending messages
+// to a Matrix room through the client-server API.
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/abgeo/maroid/libs/notifier/internal/attach"
+	"github.com/abgeo/maroid/libs/notifier/internal/markup"
+	"github.com/abgeo/maroid/libs/notifier/registry"
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+const (
+	queryParamHTTP = "x-http"
+
+	requestTimeout = 30 * time.Second
+	txnIDBytes     = 16
+	errorBodyLimit = 512
+	htmlFormat     = "org.matrix.custom.html"
+)
+
+var (
+	// ErrMissingCredentials is returned when the URL is missing the access token, homeserver or room.
+	ErrMissingCredentials = errors.New("matrix: missing access token, homeserver or room in URL")
+	// ErrUnexpectedStatus is returned when the homeserver responds with a non-2xx status.
+	ErrUnexpectedStatus = errors.New("matrix: unexpected response status")
+)
+
+// Config holds the configuration for the Matrix notifier.
+type Config struct {
+	// Homeserver is the base URL of the homeserver, e.g. https://matrix.example.org.
+	Homeserver  string
+	AccessToken string
+	RoomID      string
+}
+
+// Notifier implements the notifierapi.Transport interface for Matrix.
+type Notifier struct {
+	config *Config
+	client *http.Client
+}
+
+var _ notifierapi.Transport = (*Notifier)(nil)
+
+// New creates a new Matrix notifier from a URL configuration.
+// URL format: matrix://ACCESS_TOKEN@HOMESERVER[:PORT]/ROOM_ID?x-http=true
+// The room is a room ID such as !abc:example.org or a URL-encoded alias (%23room:example.org).
+// The homeserver is reached over HTTPS unless x-http is true.
+func New(rawURL *url.URL) (notifierapi.Transport, error) {
+	cfg, err := parseConfiguration(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		config: cfg,
+		client: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Register registers the Matrix notifier with the given registry.
+func Register(reg registry.Registry) error {
+	if err := reg.Register("matrix", New); err != nil {
+		return fmt.Errorf("registering matrix notifier: %w", err)
+	}
+
+	return nil
+}
+
+// Send posts the message as formatted text and uploads every attachment
+// as a separate image or file event.
+func (n *Notifier) Send(ctx context.Context, msg notifierapi.Message) error {
+	if msg.Title != "" || msg.Body != "" || len(msg.RichBody) > 0 {
+		err := n.sendEvent(ctx, map[string]any{
+			"msgtype":        "m.text",
+			"body":           formatPlainText(msg),
+			"format":         htmlFormat,
+			"formatted_body": formatHTML(msg),
+		})
+		if err != nil {
+			return fmt.Errorf("sending message: %w", err)
+		}
+	}
+
+	for _, attachment := range msg.Attachments {
+		if err := n.sendAttachment(ctx, attach.Normalize(attachment)); err != nil {
+			return fmt.Errorf("sending attachment %q: %w", attachment.Filename, err)
+		}
+	}
+
+	return nil
+}
+
+func parseConfiguration(rawURL *url.URL) (*Config, error) {
+	token := rawURL.User.String()
+	roomID := strings.TrimPrefix(rawURL.Path, "/")
+
+	if token == "" || rawURL.Host == "" || roomID == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	scheme := "https"
+	if rawURL.Query().Get(queryParamHTTP) == "true" {
+		scheme = "http"
+	}
+
+	return &Config{
+		Homeserver:  scheme + "://" + rawURL.Host,
+		AccessToken: token,
+		RoomID:      roomID,
+	}, nil
+}
+
+func (n *Notifier) sendAttachment(ctx context.Context, attachment notifierapi.Attachment) error {
+	contentURI, err := n.upload(ctx, attachment)
+	if err != nil {
+		return err
+	}
+
+	return n.sendEvent(ctx, map[string]any{
+		"msgtype":  messageType(attachment.MIMEType),
+		"body":     attachment.Filename,
+		"filename": attachment.Filename,
+		"url":      contentURI,
+		"info": map[string]any{
+			"mimetype": attachment.MIMEType,
+			"size":     len(attachment.Content),
+		},
+	})
+}
+
+func (n *Notifier) upload(ctx context.Context, attachment notifierapi.Attachment) (string, error) {
+	endpoint := n.config.Homeserver + "/_matrix/media/v3/upload?filename=" +
+		url.QueryEscape(attachment.Filename)
+
+	var response struct {
+		ContentURI string `json:"content_uri"`
+	}
+
+	err := n.do(ctx, http.MethodPost, endpoint, attachment.MIMEType, attachment.Content, &response)
+	if err != nil {
+		return "", fmt.Errorf("uploading: %w", err)
+	}
+
+	return response.ContentURI, nil
+}
+
+func (n *Notifier) sendEvent(ctx context.Context, content map[string]any) error {
+	txnID := make([]byte, txnIDBytes)
+	if _, err := rand.Read(txnID); err != nil {
+		return fmt.Errorf("generating transaction id: %w", err)
+	}
+
+	body, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+
+	endpoint := fmt.Sprintf(
+		"%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		n.config.Homeserver,
+		url.PathEscape(n.config.RoomID),
+		hex.EncodeToString(txnID),
+	)
+
+	return n.do(ctx, http.MethodPut, endpoint, "application/json", body, nil)
+}
+
+func (n *Notifier) do(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	contentType string,
+	body []byte,
+	response any,
+) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+n.config.AccessToken)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+
+		return fmt.Errorf("%w: %s: %s", ErrUnexpectedStatus, resp.Status, bytes.TrimSpace(snippet))
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
+
+func messageType(mimeType string) string {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return "m.image"
+	case strings.HasPrefix(mimeType, "video/"):
+		return "m.video"
+	case strings.HasPrefix(mimeType, "audio/"):
+		return "m.audio"
+	default:
+		return "m.file"
+	}
+}
+
+func formatHTML(msg notifierapi.Message) string {
+	var parts []string
+
+	if msg.Title != "" {
+		parts = append(parts, "<b>"+markup.Title(msg, markup.FormatHTML)+"</b>")
+	}
+
+	if body := markup.Body(msg, markup.FormatHTML); body != "" {
+		parts = append(parts, markup.LineBreaks(body))
+	}
+
+	return strings.Join(parts, "<br>\n<br>\n")
+}
+
+func formatPlainText(msg notifierapi.Message) string {
+	var parts []string
+
+	if msg.Title != "" {
+		parts = append(parts, msg.Title)
+	}
+
+	if body := markup.Body(msg, markup.FormatPlain); body != "" {
+		parts = append(parts, body)
+	}
+
+	return strings.Join(parts, "\n\n")
+}
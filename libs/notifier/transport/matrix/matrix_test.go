@@ -0,0 +1,204 @@
+package matrix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+const (
+	testToken  = "secret-token"
+	testRoomID = "!room:example.org"
+)
+
+// stubRequest is a request received by the stub homeserver.
+type stubRequest struct {
+	method        string
+	path          string
+	query         url.Values
+	authorization string
+	contentType   string
+	body          []byte
+}
+
+// stubHomeserver records requests and answers them with status.
+type stubHomeserver struct {
+	mu       sync.Mutex
+	requests []stubRequest
+	status   int
+}
+
+func (s *stubHomeserver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, stubRequest{
+		method:        r.Method,
+		path:          r.URL.EscapedPath(),
+		query:         r.URL.Query(),
+		authorization: r.Header.Get("Authorization"),
+		contentType:   r.Header.Get("Content-Type"),
+		body:          body,
+	})
+	s.mu.Unlock()
+
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(`{"errcode":"M_FORBIDDEN"}`))
+
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/_matrix/media/v3/upload") {
+		_, _ = w.Write([]byte(`{"content_uri":"mxc://example.org/media"}`))
+
+		return
+	}
+
+	_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+}
+
+func newTestTransport(t *testing.T, stub *stubHomeserver) notifierapi.Transport {
+	t.Helper()
+
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parsing server URL: %v", err)
+	}
+
+	parsed, err := url.Parse(
+		"matrix://" + testToken + "@" + serverURL.Host + "/" + url.PathEscape(testRoomID) +
+			"?x-http=true",
+	)
+	if err != nil {
+		t.Fatalf("parsing URL: %v", err)
+	}
+
+	transport, err := New(parsed)
+	if err != nil {
+		t.Fatalf("creating transport: %v", err)
+	}
+
+	return transport
+}
+
+func TestSendPostsFormattedMessage(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubHomeserver{}
+	transport := newTestTransport(t, stub)
+
+	err := transport.Send(context.Background(), notifierapi.Message{
+		Title: "Bill <ready>",
+		RichBody: notifierapi.RichText{
+			notifierapi.Bold("Amount"),
+			notifierapi.Text(": 10\nPaid"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("sending: %v", err)
+	}
+
+	if len(stub.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(stub.requests))
+	}
+
+	request := stub.requests[0]
+	assertRequest(t, request, http.MethodPut, "/_matrix/client/v3/rooms/"+url.PathEscape(testRoomID)+
+		"/send/m.room.message/")
+
+	var event map[string]any
+	if err = json.Unmarshal(request.body, &event); err != nil {
+		t.Fatalf("decoding event: %v", err)
+	}
+
+	want := map[string]any{
+		"msgtype":        "m.text",
+		"body":           "Bill <ready>\n\nAmount: 10\nPaid",
+		"format":         htmlFormat,
+		"formatted_body": "<b>Bill &lt;ready&gt;</b><br>\n<br>\n<b>Amount</b>: 10<br>\nPaid",
+	}
+
+	for key, value := range want {
+		if event[key] != value {
+			t.Errorf("%s: expected %q, got %q", key, value, event[key])
+		}
+	}
+}
+
+func TestSendUploadsAttachments(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubHomeserver{}
+	transport := newTestTransport(t, stub)
+
+	err := transport.Send(context.Background(), notifierapi.Message{
+		Attachments: []notifierapi.Attachment{
+			{Filename: "meter.jpg", Content: []byte("jpeg"), MIMEType: "image/jpeg"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("sending: %v", err)
+	}
+
+	if len(stub.requests) != 2 {
+		t.Fatalf("expected upload and event requests, got %d", len(stub.requests))
+	}
+
+	upload := stub.requests[0]
+	assertRequest(t, upload, http.MethodPost, "/_matrix/media/v3/upload")
+
+	filename := upload.query.Get("filename")
+	if filename != "meter.jpg" || upload.contentType != "image/jpeg" || string(upload.body) != "jpeg" {
+		t.Errorf("unexpected upload %q (%s): %q", filename, upload.contentType, upload.body)
+	}
+
+	var event map[string]any
+	if err = json.Unmarshal(stub.requests[1].body, &event); err != nil {
+		t.Fatalf("decoding event: %v", err)
+	}
+
+	if event["msgtype"] != "m.image" || event["url"] != "mxc://example.org/media" ||
+		event["body"] != "meter.jpg" {
+		t.Errorf("unexpected attachment event %v", event)
+	}
+}
+
+func TestSendReturnsErrorOnUnexpectedStatus(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubHomeserver{status: http.StatusForbidden}
+	transport := newTestTransport(t, stub)
+
+	err := transport.Send(context.Background(), notifierapi.Message{Title: "Test"})
+	if !errors.Is(err, ErrUnexpectedStatus) {
+		t.Fatalf("expected %v, got %v", ErrUnexpectedStatus, err)
+	}
+}
+
+func assertRequest(t *testing.T, request stubRequest, method string, pathPrefix string) {
+	t.Helper()
+
+	if request.method != method || !strings.HasPrefix(request.path, pathPrefix) {
+		t.Errorf(
+			"unexpected request %s %s, want %s %s...",
+			request.method, request.path, method, pathPrefix,
+		)
+	}
+
+	if request.authorization != "Bearer "+testToken {
+		t.Errorf("unexpected authorization header %q", request.authorization)
+	}
+}
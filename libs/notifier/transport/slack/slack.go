@@ -0,0 +1,318 @@
+// Package slack implements a notifier transport for sending messages
+// to a Slack channel through the Web API using a bot token.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abgeo/maroid/libs/notifier/internal/attach"
+	"github.com/abgeo/maroid/libs/notifier/internal/markup"
+	"github.com/abgeo/maroid/libs/notifier/registry"
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+const (
+	queryParamThread = "x-thread"
+	queryParamAPIURL = "x-api-url"
+
+	defaultAPIURL = "https://slack.com/api"
+
+	requestTimeout = 30 * time.Second
+	errorBodyLimit = 512
+)
+
+var (
+	// ErrMissingCredentials is returned when the URL is missing the bot token or channel ID.
+	ErrMissingCredentials = errors.New("slack: missing token or channel in URL")
+	// ErrUnexpectedStatus is returned when Slack responds with a non-2xx status.
+	ErrUnexpectedStatus = errors.New("slack: unexpected response status")
+	// ErrAPI is returned when a Slack API call responds with ok set to false.
+	ErrAPI = errors.New("slack: api error")
+)
+
+// Config holds the configuration for the Slack notifier.
+type Config struct {
+	APIURL    string
+	Token     string
+	ChannelID string
+	ThreadTS  string
+}
+
+// Notifier implements the notifierapi.Transport interface for Slack.
+type Notifier struct {
+	config *Config
+	client *http.Client
+}
+
+// apiResponse is the envelope shared by all Slack Web API responses.
+type apiResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+type postMessageRequest struct {
+	Channel  string `json:"channel"`
+	Text     string `json:"text"`
+	Mrkdwn   bool   `json:"mrkdwn"`
+	ThreadTS string `json:"thread_ts,omitempty"`
+}
+
+type completeUploadRequest struct {
+	Files          []uploadedFile `json:"files"`
+	ChannelID      string         `json:"channel_id"`
+	InitialComment string         `json:"initial_comment,omitempty"`
+	ThreadTS       string         `json:"thread_ts,omitempty"`
+}
+
+type uploadedFile struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+var _ notifierapi.Transport = (*Notifier)(nil)
+
+// New creates a new Slack notifier from a URL configuration.
+// URL format: slack://BOT_TOKEN@CHANNEL_ID?x-thread=THREAD_TS
+// The bot needs the chat:write scope, and files:write to upload attachments.
+func New(rawURL *url.URL) (notifierapi.Transport, error) {
+	cfg, err := parseConfiguration(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		config: cfg,
+		client: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Register registers the Slack notifier with the given registry.
+func Register(reg registry.Registry) error {
+	if err := reg.Register("slack", New); err != nil {
+		return fmt.Errorf("registering slack notifier: %w", err)
+	}
+
+	return nil
+}
+
+// Send posts the message as mrkdwn. With attachments, the files are uploaded
+// and shared in the channel with the text as their comment.
+func (n *Notifier) Send(ctx context.Context, msg notifierapi.Message) error {
+	text := formatMessageText(msg)
+
+	if len(msg.Attachments) == 0 {
+		return n.postMessage(ctx, text)
+	}
+
+	return n.uploadFiles(ctx, text, msg.Attachments)
+}
+
+func parseConfiguration(rawURL *url.URL) (*Config, error) {
+	token := rawURL.User.String()
+	channelID := rawURL.Host
+
+	if token == "" || channelID == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	apiURL := rawURL.Query().Get(queryParamAPIURL)
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+
+	return &Config{
+		APIURL:    strings.TrimSuffix(apiURL, "/"),
+		Token:     token,
+		ChannelID: channelID,
+		ThreadTS:  rawURL.Query().Get(queryParamThread),
+	}, nil
+}
+
+func (n *Notifier) postMessage(ctx context.Context, text string) error {
+	body, err := json.Marshal(postMessageRequest{
+		Channel:  n.config.ChannelID,
+		Text:     text,
+		Mrkdwn:   true,
+		ThreadTS: n.config.ThreadTS,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding message: %w", err)
+	}
+
+	if err = n.call(ctx, "chat.postMessage", "application/json", body, &apiResponse{}); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
+// uploadFiles uses the external upload flow: every file gets an upload URL,
+// its content is posted there, and completing the upload shares all files at once.
+func (n *Notifier) uploadFiles(
+	ctx context.Context,
+	text string,
+	attachments []notifierapi.Attachment,
+) error {
+	files := make([]uploadedFile, 0, len(attachments))
+
+	for _, attachment := range attachments {
+		attachment = attach.Normalize(attachment)
+
+		fileID, err := n.uploadFile(ctx, attachment)
+		if err != nil {
+			return fmt.Errorf("uploading attachment %q: %w", attachment.Filename, err)
+		}
+
+		files = append(files, uploadedFile{ID: fileID, Title: attachment.Filename})
+	}
+
+	body, err := json.Marshal(completeUploadRequest{
+		Files:          files,
+		ChannelID:      n.config.ChannelID,
+		InitialComment: text,
+		ThreadTS:       n.config.ThreadTS,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding upload: %w", err)
+	}
+
+	err = n.call(ctx, "files.completeUploadExternal", "application/json", body, &apiResponse{})
+	if err != nil {
+		return fmt.Errorf("completing upload: %w", err)
+	}
+
+	return nil
+}
+
+func (n *Notifier) uploadFile(
+	ctx context.Context,
+	attachment notifierapi.Attachment,
+) (string, error) {
+	form := url.Values{
+		"filename": {attachment.Filename},
+		"length":   {strconv.Itoa(len(attachment.Content))},
+	}
+
+	var response struct {
+		apiResponse
+
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+
+	err := n.call(
+		ctx,
+		"files.getUploadURLExternal",
+		"application/x-www-form-urlencoded",
+		[]byte(form.Encode()),
+		&response,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		response.UploadURL,
+		bytes.NewReader(attachment.Content),
+	)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", attachment.MIMEType)
+
+	if err = n.do(req, nil); err != nil {
+		return "", err
+	}
+
+	return response.FileID, nil
+}
+
+// call invokes a Web API method and fails if it does not report ok.
+func (n *Notifier) call(
+	ctx context.Context,
+	method string,
+	contentType string,
+	body []byte,
+	response interface{ result() apiResponse },
+) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		n.config.APIURL+"/"+method,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+n.config.Token)
+	req.Header.Set("Content-Type", contentType+"; charset=utf-8")
+
+	if err = n.do(req, response); err != nil {
+		return err
+	}
+
+	if result := response.result(); !result.OK {
+		return fmt.Errorf("%w: %s: %s", ErrAPI, method, result.Error)
+	}
+
+	return nil
+}
+
+func (n *Notifier) do(req *http.Request, response any) error {
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+
+		return fmt.Errorf("%w: %s: %s", ErrUnexpectedStatus, resp.Status, bytes.TrimSpace(snippet))
+	}
+
+	if response == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return nil
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
+
+func (r apiResponse) result() apiResponse {
+	return r
+}
+
+func formatMessageText(msg notifierapi.Message) string {
+	var parts []string
+
+	if msg.Title != "" {
+		parts = append(parts, "*"+markup.Title(msg, markup.FormatSlack)+"*")
+	}
+
+	if body := markup.Body(msg, markup.FormatSlack); body != "" {
+		parts = append(parts, body)
+	}
+
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,239 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+const (
+	testToken     = "xoxb-token"
+	testChannelID = "C0123456"
+	testFileID    = "F0123456"
+)
+
+// stubRequest is a request received by the stub Web API.
+type stubRequest struct {
+	method        string
+	path          string
+	authorization string
+	contentType   string
+	body          string
+}
+
+// stubAPI records requests and answers them like the Slack Web API.
+// When status is set, every API method responds with it; when apiError is set,
+// every API method reports it with ok set to false.
+type stubAPI struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	requests []stubRequest
+	status   int
+	apiError string
+}
+
+func (s *stubAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, stubRequest{
+		method:        r.Method,
+		path:          r.URL.Path,
+		authorization: r.Header.Get("Authorization"),
+		contentType:   r.Header.Get("Content-Type"),
+		body:          string(body),
+	})
+	s.mu.Unlock()
+
+	switch {
+	case s.status != 0:
+		w.WriteHeader(s.status)
+	case s.apiError != "":
+		_, _ = w.Write([]byte(`{"ok":false,"error":"` + s.apiError + `"}`))
+	case r.URL.Path == "/files.getUploadURLExternal":
+		_, _ = w.Write([]byte(
+			`{"ok":true,"upload_url":"` + s.server.URL + `/upload/` + testFileID +
+				`","file_id":"` + testFileID + `"}`,
+		))
+	case r.URL.Path == "/upload/"+testFileID:
+		_, _ = w.Write([]byte("OK"))
+	default:
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}
+}
+
+func newTestTransport(t *testing.T, stub *stubAPI, query string) notifierapi.Transport {
+	t.Helper()
+
+	stub.server = httptest.NewServer(stub)
+	t.Cleanup(stub.server.Close)
+
+	parsed, err := url.Parse(
+		"slack://" + testToken + "@" + testChannelID +
+			"?x-api-url=" + url.QueryEscape(stub.server.URL) + query,
+	)
+	if err != nil {
+		t.Fatalf("parsing URL: %v", err)
+	}
+
+	transport, err := New(parsed)
+	if err != nil {
+		t.Fatalf("creating transport: %v", err)
+	}
+
+	return transport
+}
+
+func TestSendPostsMrkdwnMessage(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubAPI{}
+	transport := newTestTransport(t, stub, "&x-thread=1700000000.000100")
+
+	err := transport.Send(context.Background(), notifierapi.Message{
+		Title: "Bill <ready>",
+		RichBody: notifierapi.RichText{
+			notifierapi.Bold("Amount"),
+			notifierapi.Text(": 10 "),
+			notifierapi.Link("details", "https://example.com/bill"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("sending: %v", err)
+	}
+
+	if len(stub.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(stub.requests))
+	}
+
+	request := stub.requests[0]
+	assertAPIRequest(t, request, "/chat.postMessage", "application/json; charset=utf-8")
+
+	var message postMessageRequest
+	if err = json.Unmarshal([]byte(request.body), &message); err != nil {
+		t.Fatalf("decoding message: %v", err)
+	}
+
+	want := postMessageRequest{
+		Channel:  testChannelID,
+		Text:     "*Bill &lt;ready&gt;*\n\n*Amount*: 10 <https://example.com/bill|details>",
+		Mrkdwn:   true,
+		ThreadTS: "1700000000.000100",
+	}
+	if message != want {
+		t.Errorf("unexpected message %+v, want %+v", message, want)
+	}
+}
+
+func TestSendUploadsAttachments(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubAPI{}
+	transport := newTestTransport(t, stub, "")
+
+	err := transport.Send(context.Background(), notifierapi.Message{
+		Title: "Bill",
+		Attachments: []notifierapi.Attachment{
+			{Filename: "bill.pdf", Content: []byte("%PDF-1.4"), MIMEType: "application/pdf"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("sending: %v", err)
+	}
+
+	if len(stub.requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(stub.requests))
+	}
+
+	assertAPIRequest(
+		t,
+		stub.requests[0],
+		"/files.getUploadURLExternal",
+		"application/x-www-form-urlencoded; charset=utf-8",
+	)
+
+	if want := "filename=bill.pdf&length=8"; stub.requests[0].body != want {
+		t.Errorf("unexpected upload URL request %q, want %q", stub.requests[0].body, want)
+	}
+
+	upload := stub.requests[1]
+	if upload.method != http.MethodPost || upload.path != "/upload/"+testFileID ||
+		upload.contentType != "application/pdf" || upload.body != "%PDF-1.4" {
+		t.Errorf(
+			"unexpected upload %s %s (%s): %q",
+			upload.method, upload.path, upload.contentType, upload.body,
+		)
+	}
+
+	complete := stub.requests[2]
+	assertAPIRequest(t, complete, "/files.completeUploadExternal", "application/json; charset=utf-8")
+
+	var completed completeUploadRequest
+	if err = json.Unmarshal([]byte(complete.body), &completed); err != nil {
+		t.Fatalf("decoding complete upload: %v", err)
+	}
+
+	wantFile := uploadedFile{ID: testFileID, Title: "bill.pdf"}
+	if completed.ChannelID != testChannelID || completed.InitialComment != "*Bill*" ||
+		len(completed.Files) != 1 || completed.Files[0] != wantFile {
+		t.Errorf("unexpected complete upload %+v", completed)
+	}
+}
+
+func TestSendReturnsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		stub *stubAPI
+		err  error
+	}{
+		{
+			name: "unexpected status",
+			stub: &stubAPI{status: http.StatusInternalServerError},
+			err:  ErrUnexpectedStatus,
+		},
+		{
+			name: "api error",
+			stub: &stubAPI{apiError: "channel_not_found"},
+			err:  ErrAPI,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			transport := newTestTransport(t, test.stub, "")
+
+			err := transport.Send(context.Background(), notifierapi.Message{Title: "Test"})
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func assertAPIRequest(t *testing.T, request stubRequest, path string, contentType string) {
+	t.Helper()
+
+	if request.method != http.MethodPost || request.path != path {
+		t.Errorf("unexpected request %s %s, want POST %s", request.method, request.path, path)
+	}
+
+	if request.authorization != "Bearer "+testToken {
+		t.Errorf("unexpected authorization header %q", request.authorization)
+	}
+
+	if request.contentType != contentType {
+		t.Errorf("unexpected content type %q, want %q", request.contentType, contentType)
+	}
+}
@@ -0,0 +1,216 @@
+package smtp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/abgeo/maroid/libs/notifier/internal/attach"
+	"github.com/abgeo/maroid/libs/notifier/internal/markup"
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+const (
+	base64LineLength = 76
+	messageIDBytes   = 16
+	fallbackMIMEType = "application/octet-stream"
+)
+
+// buildMessage renders msg as an RFC 5322 email. The title becomes the subject and
+// the body is sent as both HTML and plain text; attachments turn it into multipart/mixed.
+func buildMessage(cfg *Config, msg notifierapi.Message, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+
+	messageID, err := newMessageID(cfg.From.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make([]string, 0, len(cfg.To))
+	for _, recipient := range cfg.To {
+		recipients = append(recipients, recipient.String())
+	}
+
+	writeHeader(&buf, "From", cfg.From.String())
+	writeHeader(&buf, "To", strings.Join(recipients, ", "))
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", msg.Title))
+	writeHeader(&buf, "Date", now.Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", messageID)
+	writeHeader(&buf, "MIME-Version", "1.0")
+
+	alternative, alternativeType, err := buildAlternative(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(msg.Attachments) == 0 {
+		writeHeader(&buf, "Content-Type", alternativeType)
+		buf.WriteString("\r\n")
+		buf.Write(alternative)
+
+		return buf.Bytes(), nil
+	}
+
+	mixed := multipart.NewWriter(&buf)
+
+	writeHeader(&buf, "Content-Type", "multipart/mixed; boundary="+mixed.Boundary())
+	buf.WriteString("\r\n")
+
+	part, err := mixed.CreatePart(textproto.MIMEHeader{"Content-Type": {alternativeType}})
+	if err != nil {
+		return nil, fmt.Errorf("creating message body part: %w", err)
+	}
+
+	if _, err = part.Write(alternative); err != nil {
+		return nil, fmt.Errorf("writing message body part: %w", err)
+	}
+
+	for _, attachment := range msg.Attachments {
+		if err = writeAttachment(mixed, attach.Normalize(attachment)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = mixed.Close(); err != nil {
+		return nil, fmt.Errorf("closing message: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// buildAlternative renders the plain-text and HTML bodies as a multipart/alternative entity
+// and returns it along with its content type.
+func buildAlternative(msg notifierapi.Message) ([]byte, string, error) {
+	var buf bytes.Buffer
+
+	writer := multipart.NewWriter(&buf)
+
+	if err := writeTextPart(writer, "text/plain", formatPlainText(msg)); err != nil {
+		return nil, "", err
+	}
+
+	if err := writeTextPart(writer, "text/html", formatHTML(msg)); err != nil {
+		return nil, "", err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("closing message body: %w", err)
+	}
+
+	return buf.Bytes(), "multipart/alternative; boundary=" + writer.Boundary(), nil
+}
+
+func writeTextPart(writer *multipart.Writer, mediaType string, text string) error {
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mediaType + "; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return fmt.Errorf("creating %s part: %w", mediaType, err)
+	}
+
+	encoder := quotedprintable.NewWriter(part)
+
+	if _, err = io.WriteString(encoder, text); err != nil {
+		return fmt.Errorf("writing %s part: %w", mediaType, err)
+	}
+
+	if err = encoder.Close(); err != nil {
+		return fmt.Errorf("writing %s part: %w", mediaType, err)
+	}
+
+	return nil
+}
+
+func writeAttachment(writer *multipart.Writer, attachment notifierapi.Attachment) error {
+	mediaType, params, err := mime.ParseMediaType(attachment.MIMEType)
+	if err != nil {
+		mediaType, params = fallbackMIMEType, map[string]string{}
+	}
+
+	params["name"] = attachment.Filename
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {mime.FormatMediaType(mediaType, params)},
+		"Content-Disposition": {
+			mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return fmt.Errorf("creating attachment %q: %w", attachment.Filename, err)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+
+	for len(encoded) > 0 {
+		line := encoded[:min(base64LineLength, len(encoded))]
+		encoded = encoded[len(line):]
+
+		if _, err = io.WriteString(part, line+"\r\n"); err != nil {
+			return fmt.Errorf("writing attachment %q: %w", attachment.Filename, err)
+		}
+	}
+
+	return nil
+}
+
+func writeHeader(buf *bytes.Buffer, key string, value string) {
+	buf.WriteString(key + ": " + value + "\r\n")
+}
+
+func newMessageID(from string) (string, error) {
+	id := make([]byte, messageIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("generating message id: %w", err)
+	}
+
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+
+	return "<" + hex.EncodeToString(id) + "@" + domain + ">", nil
+}
+
+// formatHTML renders the message as an HTML document.
+func formatHTML(msg notifierapi.Message) string {
+	var builder strings.Builder
+
+	builder.WriteString("<!DOCTYPE html>\n<html>\n<body>\n")
+
+	if msg.Title != "" {
+		builder.WriteString("<h2>" + markup.Title(msg, markup.FormatHTML) + "</h2>\n")
+	}
+
+	if body := markup.Body(msg, markup.FormatHTML); body != "" {
+		builder.WriteString("<p>" + markup.LineBreaks(body) + "</p>\n")
+	}
+
+	builder.WriteString("</body>\n</html>\n")
+
+	return builder.String()
+}
+
+func formatPlainText(msg notifierapi.Message) string {
+	var parts []string
+
+	if msg.Title != "" {
+		parts = append(parts, msg.Title)
+	}
+
+	if body := markup.Body(msg, markup.FormatPlain); body != "" {
+		parts = append(parts, body)
+	}
+
+	return strings.Join(parts, "\n\n")
+}
@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"maps"
-	"net/http"
 	"net/url"
 	"slices"
 	"strconv"
@@ -18,6 +17,8 @@ import (
 	ta "github.com/mymmrac/telego/telegoapi"
 	tu "github.com/mymmrac/telego/telegoutil"
 
+	"github.com/abgeo/maroid/libs/notifier/internal/attach"
+	"github.com/abgeo/maroid/libs/notifier/internal/markup"
 	"github.com/abgeo/maroid/libs/notifier/registry"
 	"github.com/abgeo/maroid/libs/notifierapi"
 )
@@ -186,7 +187,7 @@ func (n *Notifier) sendTextMessage(ctx context.Context, msg notifierapi.Message)
 }
 
 func (n *Notifier) sendSingleAttachment(ctx context.Context, msg notifierapi.Message) error {
-	attachment := normalizeAttachment(msg.Attachments[0])
+	attachment := attach.Normalize(msg.Attachments[0])
 	file := tu.FileFromBytes(attachment.Content, attachment.Filename)
 	caption := formatMessageText(msg)
 
@@ -290,7 +291,7 @@ func (n *Notifier) groupAttachmentsByType(
 	grouped := make(map[string][]telego.InputMedia)
 
 	for _, attachment := range attachments {
-		attachment = normalizeAttachment(attachment)
+		attachment = attach.Normalize(attachment)
 		file := tu.FileFromBytes(attachment.Content, attachment.Filename)
 
 		if isImage(attachment) {
@@ -312,18 +313,6 @@ func setMediaCaption(media telego.InputMedia, caption string) {
 	}
 }
 
-func normalizeAttachment(attachments notifierapi.Attachment) notifierapi.Attachment {
-	if attachments.MIMEType == "" {
-		attachments.MIMEType = http.DetectContentType(attachments.Content)
-	}
-
-	if attachments.Filename == "" {
-		attachments.Filename = "attachment"
-	}
-
-	return attachments
-}
-
 func isImage(att notifierapi.Attachment) bool {
 	return strings.HasPrefix(att.MIMEType, "image/")
 }
@@ -332,11 +321,11 @@ func formatMessageText(msg notifierapi.Message) string {
 	var parts []string
 
 	if msg.Title != "" {
-		parts = append(parts, "<b>"+msg.Title+"</b>")
+		parts = append(parts, "<b>"+markup.Title(msg, markup.FormatHTML)+"</b>")
 	}
 
-	if msg.Body != "" {
-		parts = append(parts, msg.Body)
+	if body := markup.Body(msg, markup.FormatHTML); body != "" {
+		parts = append(parts, body)
 	}
 
 	return strings.Join(parts, "\n\n")
@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"text/template"
+
+	"github.com/abgeo/maroid/libs/notifier/internal/attach"
+	"github.com/abgeo/maroid/libs/notifier/internal/markup"
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// templateFuncs are available in body and header templates.
+var templateFuncs = template.FuncMap{
+	// json encodes a value as JSON, e.g. {"text": {{ json .Body }}}.
+	"json": func(value any) (string, error) {
+		encoded, err := json.Marshal(value)
+
+		return string(encoded), err //nolint:wrapcheck
+	},
+}
+
+// payload is the message as exposed to templates and sent as JSON by default.
+type payload struct {
+	Title       string              `json:"title"`
+	Body        string              `json:"body"`
+	Text        string              `json:"text"`
+	Attachments []payloadAttachment `json:"attachments,omitempty"`
+}
+
+// payloadAttachment is an attachment with base64-encoded content.
+type payloadAttachment struct {
+	Filename string `json:"filename"`
+	MIMEType string `json:"mime_type"`
+	Content  string `json:"content"`
+}
+
+// newPayload converts msg; attachments are left out in multipart mode,
+// where they are sent as separate parts.
+func newPayload(cfg *Config, msg notifierapi.Message) payload {
+	data := payload{
+		Title: msg.Title,
+		Body:  markup.Body(msg, markup.FormatHTML),
+		Text:  markup.Body(msg, markup.FormatPlain),
+	}
+
+	if cfg.Attachments == AttachmentsMultipart {
+		return data
+	}
+
+	for _, attachment := range msg.Attachments {
+		attachment = attach.Normalize(attachment)
+
+		data.Attachments = append(data.Attachments, payloadAttachment{
+			Filename: attachment.Filename,
+			MIMEType: attachment.MIMEType,
+			Content:  base64.StdEncoding.EncodeToString(attachment.Content),
+		})
+	}
+
+	return data
+}
+
+// buildPayload renders the request body and returns it along with its content type.
+func buildPayload(
+	cfg *Config,
+	data payload,
+	attachments []notifierapi.Attachment,
+) ([]byte, string, error) {
+	body, err := renderBody(cfg, data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if cfg.Attachments != AttachmentsMultipart || len(attachments) == 0 {
+		return body, cfg.ContentType, nil
+	}
+
+	var buf bytes.Buffer
+
+	writer := multipart.NewWriter(&buf)
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="payload"`},
+		"Content-Type":        {cfg.ContentType},
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("creating payload part: %w", err)
+	}
+
+	if _, err = part.Write(body); err != nil {
+		return nil, "", fmt.Errorf("writing payload part: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		attachment = attach.Normalize(attachment)
+
+		part, err = writer.CreatePart(textproto.MIMEHeader{
+			"Content-Disposition": {fmt.Sprintf(
+				`form-data; name="attachments"; filename="%s"`,
+				quoteEscaper.Replace(attachment.Filename),
+			)},
+			"Content-Type": {attachment.MIMEType},
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("creating attachment %q part: %w", attachment.Filename, err)
+		}
+
+		if _, err = part.Write(attachment.Content); err != nil {
+			return nil, "", fmt.Errorf("writing attachment %q part: %w", attachment.Filename, err)
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("closing multipart body: %w", err)
+	}
+
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+func renderBody(cfg *Config, data payload) ([]byte, error) {
+	if cfg.Body == nil {
+		body, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("encoding payload: %w", err)
+		}
+
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+
+	if err := cfg.Body.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering body template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func renderHeaders(cfg *Config, data payload) (http.Header, error) {
+	headers := make(http.Header, len(cfg.Headers))
+
+	for name, tmpl := range cfg.Headers {
+		var value strings.Builder
+
+		if err := tmpl.Execute(&value, data); err != nil {
+			return nil, fmt.Errorf("rendering %s header template: %w", name, err)
+		}
+
+		headers.Set(name, value.String())
+	}
+
+	return headers, nil
+}
@@ -0,0 +1,359 @@
+// Package webhook implements a notifier transport for posting messages
+// to arbitrary HTTP endpoints, either as JSON or through user-supplied templates.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/abgeo/maroid/libs/notifier/registry"
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+const (
+	queryParamMethod          = "x-method"
+	queryParamTemplate        = "x-template"
+	queryParamTemplateFile    = "x-template-file"
+	queryParamContentType     = "x-content-type"
+	queryParamHeaderPrefix    = "x-header-"
+	queryParamSecret          = "x-secret"
+	queryParamSignatureHeader = "x-signature-header"
+	queryParamTimeout         = "x-timeout"
+	queryParamRetries         = "x-retries"
+	queryParamBackoff         = "x-backoff"
+	queryParamAttachments     = "x-attachments"
+
+	defaultContentType     = "application/json"
+	defaultSignatureHeader = "X-Signature"
+	defaultTimeout         = 10 * time.Second
+	defaultRetries         = 3
+	defaultBackoff         = time.Second
+	maxBackoff             = 30 * time.Second
+	backoffFactor          = 2
+	errorBodyLimit         = 512
+)
+
+// AttachmentMode defines how attachments are sent.
+type AttachmentMode string
+
+const (
+	// AttachmentsBase64 embeds attachments base64-encoded in the payload.
+	AttachmentsBase64 AttachmentMode = "base64"
+	// AttachmentsMultipart sends the payload and the attachments as multipart/form-data.
+	AttachmentsMultipart AttachmentMode = "multipart"
+)
+
+var (
+	// ErrMissingHost is returned when the URL has no host.
+	ErrMissingHost = errors.New("webhook: missing host in URL")
+	// ErrInvalidTemplate is returned when the body or a header template cannot be parsed.
+	ErrInvalidTemplate = errors.New("webhook: invalid template")
+	// ErrInvalidTimeout is returned when the timeout is not a positive duration.
+	ErrInvalidTimeout = errors.New("webhook: invalid timeout")
+	// ErrInvalidRetries is returned when the retry count is not a non-negative integer.
+	ErrInvalidRetries = errors.New("webhook: invalid retries")
+	// ErrInvalidBackoff is returned when the backoff is not a positive duration.
+	ErrInvalidBackoff = errors.New("webhook: invalid backoff")
+	// ErrInvalidAttachmentMode is returned when the attachment mode is not base64 or multipart.
+	ErrInvalidAttachmentMode = errors.New("webhook: invalid attachment mode")
+	// ErrUnexpectedStatus is returned when the endpoint responds with a non-2xx status.
+	ErrUnexpectedStatus = errors.New("webhook: unexpected response status")
+)
+
+// Config holds the configuration for the webhook notifier.
+type Config struct {
+	// Endpoint is the target URL, without the x- transport parameters.
+	Endpoint        string
+	Method          string
+	ContentType     string
+	Body            *template.Template
+	Headers         map[string]*template.Template
+	Secret          []byte
+	SignatureHeader string
+	Timeout         time.Duration
+	Retries         int
+	Backoff         time.Duration
+	Attachments     AttachmentMode
+}
+
+// Notifier implements the notifierapi.Transport interface for webhooks.
+type Notifier struct {
+	config *Config
+	client *http.Client
+}
+
+var _ notifierapi.Transport = (*Notifier)(nil)
+
+// New creates a new webhook notifier from a URL configuration.
+// URL format: webhook[s]://[USER:PASSWORD@]HOST[:PORT]/PATH?QUERY&x-template=...&x-secret=...
+// webhook:// posts over HTTP and webhooks:// over HTTPS. Parameters prefixed with x- configure
+// the transport and are stripped from the endpoint:
+//   - x-method: HTTP method, POST by default
+//   - x-template / x-template-file: Go template for the body; JSON is sent when omitted
+//   - x-content-type: body content type, application/json by default
+//   - x-header-NAME: Go template for the NAME request header
+//   - x-secret / x-signature-header: HMAC-SHA256 secret and the header carrying
+//     the "sha256=<hex>" body signature, X-Signature by default
+//   - x-timeout, x-retries, x-backoff: per-attempt timeout (10s), retries (3) and
+//     initial exponential backoff (1s)
+//   - x-attachments: base64 (default) or multipart
+func New(rawURL *url.URL) (notifierapi.Transport, error) {
+	cfg, err := parseConfiguration(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		config: cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Register registers the webhook notifier for the webhook and webhooks schemes
+// with the given registry.
+func Register(reg registry.Registry) error {
+	for _, scheme := range []string{"webhook", "webhooks"} {
+		if err := reg.Register(scheme, New); err != nil {
+			return fmt.Errorf("registering %s notifier: %w", scheme, err)
+		}
+	}
+
+	return nil
+}
+
+// Send renders the message and delivers it, retrying network errors,
+// 429 and 5xx responses with exponential backoff.
+func (n *Notifier) Send(ctx context.Context, msg notifierapi.Message) error {
+	data := newPayload(n.config, msg)
+
+	body, contentType, err := buildPayload(n.config, data, msg.Attachments)
+	if err != nil {
+		return err
+	}
+
+	headers, err := renderHeaders(n.config, data)
+	if err != nil {
+		return err
+	}
+
+	headers.Set("Content-Type", contentType)
+
+	if len(n.config.Secret) > 0 {
+		headers.Set(n.config.SignatureHeader, sign(n.config.Secret, body))
+	}
+
+	backoff := n.config.Backoff
+
+	for attempt := 0; ; attempt++ {
+		retryable, err := n.post(ctx, headers, body)
+		if err == nil {
+			return nil
+		}
+
+		if !retryable || attempt >= n.config.Retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting to retry: %w", errors.Join(err, ctx.Err()))
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*backoffFactor, maxBackoff)
+	}
+}
+
+// post sends a single request and reports whether a failure is worth retrying.
+func (n *Notifier) post(ctx context.Context, headers http.Header, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		n.config.Method,
+		n.config.Endpoint,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return false, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header = headers.Clone()
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("sending request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return false, nil
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+	retryable := resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= http.StatusInternalServerError
+
+	return retryable, fmt.Errorf(
+		"%w: %s: %s",
+		ErrUnexpectedStatus,
+		resp.Status,
+		strings.TrimSpace(string(snippet)),
+	)
+}
+
+func sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func parseConfiguration(rawURL *url.URL) (*Config, error) {
+	if rawURL.Host == "" {
+		return nil, ErrMissingHost
+	}
+
+	query := rawURL.Query()
+	cfg := &Config{
+		Method:          strings.ToUpper(valueOr(query.Get(queryParamMethod), http.MethodPost)),
+		ContentType:     valueOr(query.Get(queryParamContentType), defaultContentType),
+		Headers:         make(map[string]*template.Template),
+		Secret:          []byte(query.Get(queryParamSecret)),
+		SignatureHeader: valueOr(query.Get(queryParamSignatureHeader), defaultSignatureHeader),
+		Attachments:     AttachmentMode(query.Get(queryParamAttachments)),
+	}
+
+	if cfg.Attachments == "" {
+		cfg.Attachments = AttachmentsBase64
+	}
+
+	var err error
+
+	cfg.Timeout, err = parseDuration(query.Get(queryParamTimeout), defaultTimeout, ErrInvalidTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Backoff, err = parseDuration(query.Get(queryParamBackoff), defaultBackoff, ErrInvalidBackoff)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Retries, err = parseRetries(query.Get(queryParamRetries)); err != nil {
+		return nil, err
+	}
+
+	if cfg.Attachments != AttachmentsBase64 && cfg.Attachments != AttachmentsMultipart {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAttachmentMode, cfg.Attachments)
+	}
+
+	if cfg.Body, err = parseBodyTemplate(query); err != nil {
+		return nil, err
+	}
+
+	endpointQuery := url.Values{}
+
+	for key, values := range query {
+		lowerKey := strings.ToLower(key)
+
+		switch {
+		case strings.HasPrefix(lowerKey, queryParamHeaderPrefix):
+			name := http.CanonicalHeaderKey(key[len(queryParamHeaderPrefix):])
+
+			if cfg.Headers[name], err = parseTemplate(name, values[0]); err != nil {
+				return nil, err
+			}
+		case !strings.HasPrefix(lowerKey, "x-"):
+			endpointQuery[key] = values
+		}
+	}
+
+	endpoint := *rawURL
+	endpoint.Scheme = "http"
+
+	if rawURL.Scheme == "webhooks" {
+		endpoint.Scheme = "https"
+	}
+
+	endpoint.RawQuery = endpointQuery.Encode()
+	cfg.Endpoint = endpoint.String()
+
+	return cfg, nil
+}
+
+func parseBodyTemplate(query url.Values) (*template.Template, error) {
+	text := query.Get(queryParamTemplate)
+
+	if path := query.Get(queryParamTemplateFile); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading %s: %w", ErrInvalidTemplate, path, err)
+		}
+
+		text = string(content)
+	}
+
+	if text == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	return parseTemplate("body", text)
+}
+
+func parseTemplate(name string, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidTemplate, name, err)
+	}
+
+	return tmpl, nil
+}
+
+func parseDuration(raw string, fallback time.Duration, invalidErr error) (time.Duration, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(raw)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("%w: %q", invalidErr, raw)
+	}
+
+	return duration, nil
+}
+
+func parseRetries(raw string) (int, error) {
+	if raw == "" {
+		return defaultRetries, nil
+	}
+
+	retries, err := strconv.Atoi(raw)
+	if err != nil || retries < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRetries, raw)
+	}
+
+	return retries, nil
+}
+
+func valueOr(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
@@ -8,8 +8,20 @@ type Attachment struct {
 }
 
 // Message defines the structure of a notification message.
+// Title is plain text. RichBody is the preferred, format-neutral body; when it is empty,
+// Body is used instead and treated as Telegram HTML for backwards compatibility.
 type Message struct {
 	Title       string
 	Body        string
+	RichBody    RichText
 	Attachments []Attachment
 }
+
+// Text returns the body as plain text: the rendered RichBody, or Body as is.
+func (m Message) Text() string {
+	if len(m.RichBody) > 0 {
+		return m.RichBody.Plain()
+	}
+
+	return m.Body
+}
@@ -12,7 +12,9 @@ type Transport interface {
 // Dispatcher defines an abstraction for sending notification messages
 // to one or more logical channels. Each channel may include multiple
 // transports (e.g., Telegram, Email, Webhook) for message delivery.
+// Send returns a per-transport delivery report along with an error
+// when the channel delivery strategy is not satisfied.
 type Dispatcher interface {
-	Send(ctx context.Context, channelName string, msg Message) error
+	Send(ctx context.Context, channelName string, msg Message) (DeliveryReport, error)
 	Channels() []string
 }
@@ -0,0 +1,61 @@
+package notifierapi
+
+import "time"
+
+// DeliveryStatus is the outcome of delivering a message through a single transport.
+type DeliveryStatus string
+
+// Delivery statuses.
+const (
+	// DeliveryDelivered means the transport accepted the message.
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryFailed means the transport returned an error.
+	DeliveryFailed DeliveryStatus = "failed"
+	// DeliverySkipped means the transport was not tried because the delivery
+	// strategy of the channel was already satisfied.
+	DeliverySkipped DeliveryStatus = "skipped"
+	// DeliveryQueued means the message was accepted for asynchronous delivery.
+	DeliveryQueued DeliveryStatus = "queued"
+)
+
+// TransportDelivery is the outcome of delivering a message through a single transport.
+type TransportDelivery struct {
+	Transport string
+	// Fallback reports whether the transport is one of the channel fallbacks.
+	Fallback bool
+	Status   DeliveryStatus
+	// Err is set when Status is DeliveryFailed.
+	Err      error
+	Duration time.Duration
+}
+
+// DeliveryReport describes how a message was delivered through the transports of a channel,
+// in the order they were configured.
+type DeliveryReport struct {
+	Channel    string
+	Deliveries []TransportDelivery
+}
+
+// Delivered reports whether at least one transport delivered or queued the message.
+func (r DeliveryReport) Delivered() bool {
+	for _, delivery := range r.Deliveries {
+		if delivery.Status == DeliveryDelivered || delivery.Status == DeliveryQueued {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Failed returns the deliveries that failed.
+func (r DeliveryReport) Failed() []TransportDelivery {
+	var failed []TransportDelivery
+
+	for _, delivery := range r.Deliveries {
+		if delivery.Status == DeliveryFailed {
+			failed = append(failed, delivery)
+		}
+	}
+
+	return failed
+}
@@ -0,0 +1,209 @@
+package notifierapi
+
+import "strings"
+
+// Style is a set of inline text styles.
+type Style uint8
+
+// Inline text styles; they can be combined.
+const (
+	StyleBold Style = 1 << iota
+	StyleItalic
+	StyleStrikethrough
+	StyleCode
+)
+
+// Span is a run of uniformly styled text. Newlines in Text are line breaks.
+type Span struct {
+	Text  string
+	Style Style
+	// URL turns the span into a link when set.
+	URL string
+}
+
+// RichText is a format-neutral rich text made of spans.
+// Transports render it to their native markup and escape the text,
+// so spans may safely contain user data.
+type RichText []Span
+
+// richTextFormat describes how a markup renders spans.
+type richTextFormat struct {
+	escape     func(text string) string
+	escapeCode func(text string) string
+	// markers holds the opening and closing markers of the styles, innermost first.
+	markers []styleMarker
+	link    func(text string, url string) string
+}
+
+type styleMarker struct {
+	style Style
+	open  string
+	close string
+}
+
+var (
+	htmlFormat = richTextFormat{
+		escape:     htmlEscaper.Replace,
+		escapeCode: htmlEscaper.Replace,
+		markers: []styleMarker{
+			{StyleCode, "<code>", "</code>"},
+			{StyleStrikethrough, "<s>", "</s>"},
+			{StyleItalic, "<i>", "</i>"},
+			{StyleBold, "<b>", "</b>"},
+		},
+		link: func(text string, url string) string {
+			return `<a href="` + htmlEscaper.Replace(url) + `">` + text + "</a>"
+		},
+	}
+
+	markdownFormat = richTextFormat{
+		escape: strings.NewReplacer(
+			`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`",
+			"|", `\|`, ">", `\>`, "[", `\[`, "]", `\]`,
+		).Replace,
+		escapeCode: func(text string) string { return text },
+		markers: []styleMarker{
+			{StyleCode, "`", "`"},
+			{StyleStrikethrough, "~~", "~~"},
+			{StyleItalic, "*", "*"},
+			{StyleBold, "**", "**"},
+		},
+		link: func(text string, url string) string {
+			return "[" + text + "](" + url + ")"
+		},
+	}
+
+	slackFormat = richTextFormat{
+		escape:     slackEscaper.Replace,
+		escapeCode: slackEscaper.Replace,
+		markers: []styleMarker{
+			{StyleCode, "`", "`"},
+			{StyleStrikethrough, "~", "~"},
+			{StyleItalic, "_", "_"},
+			{StyleBold, "*", "*"},
+		},
+		link: func(text string, url string) string {
+			return "<" + url + "|" + text + ">"
+		},
+	}
+
+	plainFormat = richTextFormat{
+		escape:     func(text string) string { return text },
+		escapeCode: func(text string) string { return text },
+		link: func(text string, url string) string {
+			if text == url {
+				return text
+			}
+
+			return text + " (" + url + ")"
+		},
+	}
+
+	// htmlEscaper escapes the characters Telegram and email clients require,
+	// keeping the output readable for non-ASCII text.
+	htmlEscaper  = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+	slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// Text returns an unstyled span.
+func Text(text string) Span {
+	return Span{Text: text}
+}
+
+// Bold returns a bold span.
+func Bold(text string) Span {
+	return Span{Text: text, Style: StyleBold}
+}
+
+// Italic returns an italic span.
+func Italic(text string) Span {
+	return Span{Text: text, Style: StyleItalic}
+}
+
+// Strikethrough returns a struck-through span.
+func Strikethrough(text string) Span {
+	return Span{Text: text, Style: StyleStrikethrough}
+}
+
+// Code returns a monospace span.
+func Code(text string) Span {
+	return Span{Text: text, Style: StyleCode}
+}
+
+// Link returns a span linking to url.
+func Link(text string, url string) Span {
+	return Span{Text: text, URL: url}
+}
+
+// With returns a copy of the span with the given styles added.
+func (s Span) With(style Style) Span {
+	s.Style |= style
+
+	return s
+}
+
+// HTML renders the text as the HTML subset supported by Telegram.
+// Line breaks are kept as newlines; wrap them in <br> for HTML documents.
+func (t RichText) HTML() string {
+	return t.render(htmlFormat)
+}
+
+// Markdown renders the text as Markdown as understood by Discord.
+func (t RichText) Markdown() string {
+	return t.render(markdownFormat)
+}
+
+// Slack renders the text as Slack mrkdwn.
+func (t RichText) Slack() string {
+	return t.render(slackFormat)
+}
+
+// Plain renders the text without markup; links keep their URL in parentheses.
+func (t RichText) Plain() string {
+	return t.render(plainFormat)
+}
+
+// String renders the text without markup.
+func (t RichText) String() string {
+	return t.Plain()
+}
+
+func (t RichText) render(format richTextFormat) string {
+	var builder strings.Builder
+
+	for _, span := range t {
+		// Markers are applied per line, since most markups do not let styles span line breaks.
+		for i, line := range strings.Split(span.Text, "\n") {
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+
+			builder.WriteString(format.renderLine(line, span))
+		}
+	}
+
+	return builder.String()
+}
+
+func (f richTextFormat) renderLine(line string, span Span) string {
+	if line == "" {
+		return ""
+	}
+
+	text := f.escape(line)
+	if span.Style&StyleCode != 0 {
+		text = f.escapeCode(line)
+	}
+
+	for _, marker := range f.markers {
+		if span.Style&marker.style != 0 {
+			text = marker.open + text + marker.close
+		}
+	}
+
+	if span.URL != "" {
+		text = f.link(text, span.URL)
+	}
+
+	return text
+}
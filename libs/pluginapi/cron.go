@@ -1,11 +1,20 @@
 package pluginapi
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // CronJobMeta represents the CronJob metadata.
+//
+// Schedule accepts standard 5-field specs, 6-field specs with a leading seconds field,
+// descriptors such as @monthly or @every 1h, and an optional CRON_TZ= prefix.
+// Timezone is an IANA time zone name (e.g. "Asia/Tbilisi") the schedule is evaluated in;
+// when empty, the host-wide cron time zone is used. A CRON_TZ= prefix takes precedence.
 type CronJobMeta struct {
 	ID       string
 	Schedule string
+	Timezone string
 }
 
 // CronPlugin is a plugin that can register scheduled cron jobs.
@@ -19,3 +28,34 @@ type CronJob interface {
 	Meta() CronJobMeta
 	Run(ctx context.Context) error
 }
+
+// BackfillableJob is a cron job that can also process an explicit historical period.
+// The host calls Backfill once per period when collecting history (e.g. month by month).
+type BackfillableJob interface {
+	CronJob
+	Backfill(ctx context.Context, period Period) error
+}
+
+// Period represents an inclusive range of calendar days.
+// Both From and To point to midnight of the first and the last day of the range.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// MonthPeriod returns the period covering the calendar month that contains t.
+func MonthPeriod(t time.Time) Period {
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+
+	return Period{
+		From: from,
+		To:   from.AddDate(0, 1, -1),
+	}
+}
+
+// PreviousMonthPeriod returns the period covering the calendar month before the one that contains t.
+func PreviousMonthPeriod(t time.Time) Period {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+
+	return MonthPeriod(firstOfMonth.AddDate(0, 0, -1))
+}
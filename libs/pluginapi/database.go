@@ -29,7 +29,8 @@ func NewPluginDB(db *sqlx.DB, pluginID *PluginID) *PluginDB {
 }
 
 // WithTx executes a function within a database transaction, setting the search_path
-// to the plugin-specific schema.
+// to the plugin-specific schema. In dry-run mode (see WithDryRun) the transaction
+// is always rolled back.
 func (p *PluginDB) WithTx(ctx context.Context, fn func(*sqlx.Tx) error) error {
 	tx, err := p.db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -50,6 +51,10 @@ func (p *PluginDB) WithTx(ctx context.Context, fn func(*sqlx.Tx) error) error {
 		return err
 	}
 
+	if IsDryRun(ctx) {
+		return nil
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
@@ -0,0 +1,66 @@
+package pluginapi
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/abgeo/maroid/libs/notifierapi"
+)
+
+type dryRunKey struct{}
+
+// DryRunNotification is a notification that was recorded instead of being sent during a dry run.
+type DryRunNotification struct {
+	Channel string
+	Message notifierapi.Message
+}
+
+// DryRun collects the side effects suppressed during a dry run execution.
+// In dry-run mode PluginDB.WithTx always rolls back, the notifier dispatcher
+// records messages instead of sending them and Telegram bot calls are only logged.
+type DryRun struct {
+	mu            sync.Mutex
+	notifications []DryRunNotification
+}
+
+// WithDryRun returns a copy of ctx that carries the dry-run mode and the recorder
+// collecting suppressed side effects.
+func WithDryRun(ctx context.Context) (context.Context, *DryRun) {
+	dryRun := &DryRun{}
+
+	return context.WithValue(ctx, dryRunKey{}, dryRun), dryRun
+}
+
+// DryRunFromContext returns the dry-run recorder carried by ctx, if any.
+func DryRunFromContext(ctx context.Context) (*DryRun, bool) {
+	dryRun, ok := ctx.Value(dryRunKey{}).(*DryRun)
+
+	return dryRun, ok
+}
+
+// IsDryRun reports whether ctx carries the dry-run mode.
+func IsDryRun(ctx context.Context) bool {
+	_, ok := DryRunFromContext(ctx)
+
+	return ok
+}
+
+// RecordNotification records a notification that would have been sent to the channel.
+func (d *DryRun) RecordNotification(channel string, msg notifierapi.Message) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.notifications = append(d.notifications, DryRunNotification{
+		Channel: channel,
+		Message: msg,
+	})
+}
+
+// Notifications returns the recorded notifications in the order they were sent.
+func (d *DryRun) Notifications() []DryRunNotification {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return slices.Clone(d.notifications)
+}
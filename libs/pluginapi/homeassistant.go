@@ -0,0 +1,61 @@
+package pluginapi
+
+import (
+	"context"
+	"time"
+)
+
+// Home Assistant entity components.
+const (
+	HomeAssistantSensor       HomeAssistantComponent = "sensor"
+	HomeAssistantBinarySensor HomeAssistantComponent = "binary_sensor"
+	HomeAssistantButton       HomeAssistantComponent = "button"
+)
+
+// Home Assistant binary sensor states.
+const (
+	HomeAssistantOn  = "ON"
+	HomeAssistantOff = "OFF"
+)
+
+// HomeAssistantComponent is the Home Assistant platform an entity belongs to.
+type HomeAssistantComponent string
+
+// HomeAssistantEntityPlugin is a plugin that exposes entities to Home Assistant.
+// The hub announces them through MQTT discovery, grouped under one device per plugin.
+type HomeAssistantEntityPlugin interface {
+	Plugin
+	HomeAssistantEntities() ([]HomeAssistantEntity, error)
+}
+
+// HomeAssistantEntityMeta holds metadata for a Home Assistant entity.
+type HomeAssistantEntityMeta struct {
+	ID                string                 // unique within the plugin; [a-z0-9_]+
+	Component         HomeAssistantComponent // sensor, binary_sensor or button
+	Name              string                 // friendly name shown in Home Assistant
+	DeviceClass       string                 // optional; e.g. "temperature", "moisture", "monetary"
+	StateClass        string                 // optional; sensors only, e.g. "measurement"
+	UnitOfMeasurement string                 // optional; sensors only
+	Icon              string                 // optional; e.g. "mdi:car"
+	// StateInterval is how often the state is polled; the hub default applies when zero.
+	StateInterval time.Duration
+}
+
+// HomeAssistantEntity is an entity exposed to Home Assistant.
+// Sensors and binary sensors implement HomeAssistantStateEntity, buttons HomeAssistantButtonEntity.
+type HomeAssistantEntity interface {
+	Meta() HomeAssistantEntityMeta
+}
+
+// HomeAssistantStateEntity is a sensor or binary sensor whose state the hub polls and publishes.
+// Binary sensors return HomeAssistantOn or HomeAssistantOff.
+type HomeAssistantStateEntity interface {
+	HomeAssistantEntity
+	State(ctx context.Context) (string, error)
+}
+
+// HomeAssistantButtonEntity is a button; Press is called when it is pressed in Home Assistant.
+type HomeAssistantButtonEntity interface {
+	HomeAssistantEntity
+	Press(ctx context.Context) error
+}
@@ -17,4 +17,7 @@ type Host interface {
 	Notifier() (notifierapi.Dispatcher, error)
 	TelegramBot() (TelegramBot, error)
 	TelegramConversationEngine() conversation.Engine
+	Scheduler() (Scheduler, error)
+	Tasks() (TaskQueue, error)
+	MQTT() (MQTTPublisher, error)
 }
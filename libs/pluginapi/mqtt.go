@@ -1,12 +1,32 @@
 package pluginapi
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// MQTT payload codecs.
+const (
+	MQTTCodecJSON     MQTTCodec = "json"
+	MQTTCodecCBOR     MQTTCodec = "cbor"
+	MQTTCodecSenML    MQTTCodec = "senml" // SenML JSON (RFC 8428), decoded into *SenMLPack
+	MQTTCodecProtobuf MQTTCodec = "protobuf"
+)
+
+// MQTTCodec identifies how the payload of an MQTTTypedSubscriber is decoded.
+type MQTTCodec string
 
 // MQTTSubscriberMeta holds metadata for an MQTT subscriber.
+// Topic segments written as {name} match a single level like + and are delivered
+// to typed subscribers as named parameters,
+// e.g. "{source_type}/{source_id}/measurement/{metric}".
 type MQTTSubscriberMeta struct {
-	ID    string // unique identifier for the subscriber
-	Topic string // relative topic pattern; wildcards + and # are allowed
-	QoS   byte   // 0, 1, or 2
+	ID          string    // unique identifier for the subscriber
+	Topic       string    // relative topic pattern; wildcards +, # and {name} are allowed
+	QoS         byte      // 0, 1, or 2
+	Codec       MQTTCodec // optional; payload codec of typed subscribers, JSON by default
+	Concurrency int       // optional; max concurrent handlers, overrides the hub default when > 0
+	Ordered     bool      // optional; handle same-topic messages one at a time, in arrival order
 }
 
 // MQTTSubscriberPlugin is a plugin that can register MQTT topic subscribers.
@@ -20,3 +40,74 @@ type MQTTSubscriber interface {
 	Meta() MQTTSubscriberMeta
 	Handle(ctx context.Context, topic string, payload []byte) error
 }
+
+// MQTTUserProperty is an MQTT v5 user property.
+type MQTTUserProperty struct {
+	Key   string
+	Value string
+}
+
+// MQTTProperties holds the MQTT v5 properties of a received message.
+// All fields are zero for messages received over MQTT v3.1.1.
+type MQTTProperties struct {
+	ContentType     string
+	ResponseTopic   string
+	CorrelationData []byte
+	MessageExpiry   time.Duration // remaining message lifetime; zero if the message does not expire
+	UserProperties  []MQTTUserProperty
+}
+
+// MQTTPropertiesSubscriber is an MQTTSubscriber that also receives MQTT v5 message properties.
+// When a subscriber implements it, HandleWithProperties is called instead of Handle.
+type MQTTPropertiesSubscriber interface {
+	MQTTSubscriber
+	HandleWithProperties(
+		ctx context.Context,
+		topic string,
+		payload []byte,
+		props MQTTProperties,
+	) error
+}
+
+// MQTTMessage is a decoded MQTT message delivered to an MQTTTypedSubscriber.
+type MQTTMessage struct {
+	Topic      string            // topic relative to the plugin namespace
+	Params     map[string]string // named topic parameters
+	Payload    []byte            // raw payload
+	Value      any               // decoded payload, as returned by NewValue
+	Properties MQTTProperties
+}
+
+// MQTTTypedSubscriber is an MQTTSubscriber whose topic parameters and payload are decoded by the hub.
+// NewValue returns a pointer the payload is decoded into using the codec declared in Meta;
+// for senml it must be a *SenMLPack and for protobuf a proto.Message. When a subscriber
+// implements it, HandleMessage is called instead of Handle. Messages that fail to decode never
+// reach the subscriber: they are logged and dead-lettered by the hub.
+type MQTTTypedSubscriber interface {
+	MQTTSubscriber
+	NewValue() any
+	HandleMessage(ctx context.Context, msg MQTTMessage) error
+}
+
+// SenMLRecord is a resolved SenML record: base fields are applied to the record fields
+// and the time is absolute.
+type SenMLRecord struct {
+	Name        string
+	Unit        string
+	Time        time.Time
+	Value       *float64
+	StringValue *string
+	BoolValue   *bool
+	DataValue   *string
+	Sum         *float64
+}
+
+// SenMLPack is a decoded SenML pack.
+type SenMLPack []SenMLRecord
+
+// MQTTPublisher publishes messages to topics within the plugin namespace.
+// The relative topic is prefixed with the plugin namespace, the same way subscriber topics are;
+// it must not contain wildcards.
+type MQTTPublisher interface {
+	Publish(ctx context.Context, relativeTopic string, payload []byte, qos byte, retained bool) error
+}
@@ -0,0 +1,52 @@
+package pluginapi
+
+import (
+	"context"
+	"time"
+)
+
+// ScheduledTaskHandlerMeta represents the ScheduledTaskHandler metadata.
+type ScheduledTaskHandlerMeta struct {
+	ID string
+}
+
+// ScheduledTaskPlugin is a plugin that can run tasks scheduled at runtime through Host.Scheduler().
+type ScheduledTaskPlugin interface {
+	Plugin
+	ScheduledTaskHandlers() ([]ScheduledTaskHandler, error)
+}
+
+// ScheduledTaskHandler runs tasks scheduled for it. The payload is the one given to Scheduler.Schedule.
+type ScheduledTaskHandler interface {
+	Meta() ScheduledTaskHandlerMeta
+	Run(ctx context.Context, payload []byte) error
+}
+
+// ScheduledTask describes a delayed or recurring task.
+//
+// Handler is the ID of the ScheduledTaskHandler that runs the task.
+// Key optionally identifies the task for its handler: scheduling a task with the key
+// of an existing task replaces it. RunAt is the time of the first run; a zero value means now.
+// A positive Every makes the task recurring with the given interval; a failed run is then
+// not retried before the next interval. Otherwise the task runs once: it is removed when it
+// succeeds, and a failed run is retried with exponential backoff up to the number of attempts
+// configured for the scheduler, after which the task is kept as failed and no longer run.
+type ScheduledTask struct {
+	Handler string
+	Key     string
+	Payload []byte
+	RunAt   time.Time
+	Every   time.Duration
+}
+
+// Scheduler schedules and cancels tasks at runtime.
+// Tasks are persisted and run by the worker, so they survive restarts.
+type Scheduler interface {
+	// Schedule persists the task and returns its ID.
+	Schedule(ctx context.Context, task ScheduledTask) (string, error)
+	// Cancel removes the task with the given ID. Cancelling a missing task is not an error.
+	Cancel(ctx context.Context, id string) error
+	// CancelByKey removes the task with the given handler and key.
+	// Cancelling a missing task is not an error.
+	CancelByKey(ctx context.Context, handler string, key string) error
+}
@@ -0,0 +1,54 @@
+package pluginapi
+
+import (
+	"context"
+	"time"
+)
+
+// TaskHandlerMeta represents the TaskHandler metadata.
+// Type is the task type the handler processes.
+type TaskHandlerMeta struct {
+	Type string
+}
+
+// TaskHandlerPlugin is a plugin that can process tasks enqueued through Host.Tasks().
+type TaskHandlerPlugin interface {
+	Plugin
+	TaskHandlers() ([]TaskHandler, error)
+}
+
+// TaskHandler processes queued tasks of a single type.
+// Returning an error schedules a retry with exponential backoff until the attempts are exhausted.
+type TaskHandler interface {
+	Meta() TaskHandlerMeta
+	Handle(ctx context.Context, task Task) error
+}
+
+// Task is a queued task delivered to its handler.
+// Attempt starts at 1 for the first execution.
+type Task struct {
+	ID          string
+	Type        string
+	Payload     []byte
+	Attempt     int
+	MaxAttempts int
+}
+
+// TaskOptions configures an enqueued task. Zero values fall back to the host defaults.
+//
+// RunAt delays the first attempt. MaxAttempts bounds the number of executions.
+// Backoff is the delay before the first retry; it doubles with every further attempt.
+// UniqueKey deduplicates tasks of the same type: while a task with the key is pending
+// or running, enqueueing another one returns the ID of the existing task.
+type TaskOptions struct {
+	RunAt       time.Time
+	MaxAttempts int
+	Backoff     time.Duration
+	UniqueKey   string
+}
+
+// TaskQueue enqueues tasks that are persisted and processed by the queue worker.
+type TaskQueue interface {
+	// Enqueue persists a task of the given type and returns its ID.
+	Enqueue(ctx context.Context, taskType string, payload []byte, opts TaskOptions) (string, error)
+}
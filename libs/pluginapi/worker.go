@@ -0,0 +1,24 @@
+package pluginapi
+
+import "context"
+
+// WorkerPlugin is a plugin that can provide long-running background workers,
+// such as polling loops or persistent socket connections.
+// Workers are registered as "plugin:<plugin id>:<worker name>" and run by `maroid worker`.
+type WorkerPlugin interface {
+	Plugin
+	Workers() ([]Worker, error)
+}
+
+// Worker represents a background worker that can be started and stopped.
+type Worker interface {
+	// Name returns a stable, lowercase identifier for the worker, unique within the plugin.
+	Name() string
+	// Prepare validates configuration and performs pre-start setup.
+	// All workers are prepared before any worker is started.
+	Prepare() error
+	// Start begins the worker's main loop and blocks until ctx is cancelled.
+	Start(ctx context.Context) error
+	// Stop gracefully shuts down the worker within the provided context deadline.
+	Stop(ctx context.Context) error
+}
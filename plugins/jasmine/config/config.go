@@ -0,0 +1,23 @@
+// Package config defines the configuration schema for the plugin.
+package config
+
+// HomeAssistantSensor defines a measurement exposed to Home Assistant as a sensor.
+// The sensor reports the latest value of MetricType measured for the source.
+type HomeAssistantSensor struct {
+	SourceType        string `mapstructure:"source_type"         validate:"oneof=plant environment"`
+	SourceID          string `mapstructure:"source_id"           validate:"required"`
+	MetricType        string `mapstructure:"metric_type"         validate:"required"`
+	Name              string `mapstructure:"name"                validate:"required"`
+	DeviceClass       string `mapstructure:"device_class"`
+	UnitOfMeasurement string `mapstructure:"unit_of_measurement"`
+}
+
+// HomeAssistant defines the Home Assistant integration settings.
+type HomeAssistant struct {
+	Sensors []HomeAssistantSensor `mapstructure:"sensors" validate:"dive"`
+}
+
+// Config represents the root plugin configuration.
+type Config struct {
+	HomeAssistant HomeAssistant `mapstructure:"home_assistant"`
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/libs/pluginapi"
+	"github.com/abgeo/maroid/plugins/jasmine/config"
+	"github.com/abgeo/maroid/plugins/jasmine/model"
+	"github.com/abgeo/maroid/plugins/jasmine/repository"
+)
+
+var invalidIDChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Measurement is a sensor that reports the latest value of a metric measured for a source.
+type Measurement struct {
+	db     *pluginapi.PluginDB
+	sensor config.HomeAssistantSensor
+}
+
+var _ pluginapi.HomeAssistantStateEntity = (*Measurement)(nil)
+
+// NewMeasurement creates a new Measurement.
+func NewMeasurement(db *pluginapi.PluginDB, sensor config.HomeAssistantSensor) *Measurement {
+	return &Measurement{
+		db:     db,
+		sensor: sensor,
+	}
+}
+
+// Meta returns the metadata for the entity.
+// The ID is derived from the source and the metric type.
+func (e *Measurement) Meta() pluginapi.HomeAssistantEntityMeta {
+	id := strings.ToLower(e.sensor.SourceType + "_" + e.sensor.SourceID + "_" + e.sensor.MetricType)
+
+	return pluginapi.HomeAssistantEntityMeta{
+		ID:                invalidIDChars.ReplaceAllString(id, "_"),
+		Component:         pluginapi.HomeAssistantSensor,
+		Name:              e.sensor.Name,
+		DeviceClass:       e.sensor.DeviceClass,
+		StateClass:        "measurement",
+		UnitOfMeasurement: e.sensor.UnitOfMeasurement,
+		Icon:              "mdi:sprout",
+	}
+}
+
+// State returns the latest measured value.
+func (e *Measurement) State(ctx context.Context) (string, error) {
+	var measurement *model.Measurement
+
+	err := e.db.WithTx(ctx, func(tx *sqlx.Tx) error {
+		var err error
+
+		measurement, err = repository.NewMeasurement(tx).GetLatest(
+			ctx,
+			model.SourceType(e.sensor.SourceType),
+			e.sensor.SourceID,
+			e.sensor.MetricType,
+		)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return "", fmt.Errorf("fetching latest measurement: %w", err)
+	}
+
+	return strconv.FormatFloat(measurement.Value, 'f', -1, 64), nil
+}
@@ -7,31 +7,45 @@ import (
 	"log/slog"
 
 	"github.com/abgeo/maroid/libs/pluginapi"
+	"github.com/abgeo/maroid/libs/pluginconfig"
+	"github.com/abgeo/maroid/plugins/jasmine/config"
 	"github.com/abgeo/maroid/plugins/jasmine/db"
+	"github.com/abgeo/maroid/plugins/jasmine/homeassistant/entity"
 	"github.com/abgeo/maroid/plugins/jasmine/mqtt/subscriber"
 )
 
 type JasminePlugin struct {
+	config *config.Config
 	logger *slog.Logger
 	db     *pluginapi.PluginDB
 }
 
 var (
-	_ pluginapi.Plugin               = (*JasminePlugin)(nil)
-	_ pluginapi.MQTTSubscriberPlugin = (*JasminePlugin)(nil)
-	_ pluginapi.MigrationPlugin      = (*JasminePlugin)(nil)
+	_ pluginapi.Plugin                    = (*JasminePlugin)(nil)
+	_ pluginapi.HomeAssistantEntityPlugin = (*JasminePlugin)(nil)
+	_ pluginapi.MQTTSubscriberPlugin      = (*JasminePlugin)(nil)
+	_ pluginapi.MigrationPlugin           = (*JasminePlugin)(nil)
 )
 
 // New creates a plugin instance.
 //
 //nolint:gochecknoglobals
-var New pluginapi.Constructor = func(host pluginapi.Host, _ map[string]any) (pluginapi.Plugin, error) {
+var New pluginapi.Constructor = func(host pluginapi.Host, cfg map[string]any) (pluginapi.Plugin, error) {
+	pluginConfig := new(config.Config)
+
+	err := pluginconfig.DecodeAndValidateConfig(cfg, pluginConfig)
+	if err != nil {
+		return nil, fmt.Errorf("validating config: %w", err)
+	}
+
 	database, err := host.Database()
 	if err != nil {
 		return nil, fmt.Errorf("getting host database instance: %w", err)
 	}
 
-	plg := &JasminePlugin{}
+	plg := &JasminePlugin{
+		config: pluginConfig,
+	}
 
 	plg.db = pluginapi.NewPluginDB(database, plg.Meta().ID)
 
@@ -52,6 +66,16 @@ func (p *JasminePlugin) Meta() pluginapi.Metadata {
 	}
 }
 
+func (p *JasminePlugin) HomeAssistantEntities() ([]pluginapi.HomeAssistantEntity, error) {
+	entities := make([]pluginapi.HomeAssistantEntity, 0, len(p.config.HomeAssistant.Sensors))
+
+	for _, sensor := range p.config.HomeAssistant.Sensors {
+		entities = append(entities, entity.NewMeasurement(p.db, sensor))
+	}
+
+	return entities, nil
+}
+
 func (p *JasminePlugin) MQTTSubscribers() ([]pluginapi.MQTTSubscriber, error) {
 	return []pluginapi.MQTTSubscriber{
 		subscriber.NewMeasurementSubscriber(p.logger, p.db),
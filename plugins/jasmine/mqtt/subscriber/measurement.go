@@ -2,10 +2,9 @@ package subscriber
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
-	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -15,6 +14,11 @@ import (
 	"github.com/abgeo/maroid/plugins/jasmine/repository"
 )
 
+var (
+	errUntypedDelivery = errors.New("measurement messages are delivered via HandleMessage")
+	errUnexpectedValue = errors.New("unexpected measurement value")
+)
+
 type sensorReading struct {
 	Time  time.Time `json:"time"`
 	Value float64   `json:"value"`
@@ -26,7 +30,7 @@ type MeasurementSubscriber struct {
 	db     *pluginapi.PluginDB
 }
 
-var _ pluginapi.MQTTSubscriber = (*MeasurementSubscriber)(nil)
+var _ pluginapi.MQTTTypedSubscriber = (*MeasurementSubscriber)(nil)
 
 // NewMeasurementSubscriber creates a new MeasurementSubscriber.
 func NewMeasurementSubscriber(logger *slog.Logger, db *pluginapi.PluginDB) *MeasurementSubscriber {
@@ -39,28 +43,39 @@ func NewMeasurementSubscriber(logger *slog.Logger, db *pluginapi.PluginDB) *Meas
 // Meta returns the subscriber metadata.
 func (s *MeasurementSubscriber) Meta() pluginapi.MQTTSubscriberMeta {
 	return pluginapi.MQTTSubscriberMeta{
-		ID: "measurement",
-		// {source_type}/{source_id}/measurement/{metric_type}
-		Topic: "+/+/measurement/+",
+		ID:    "measurement",
+		Topic: "{source_type}/{source_id}/measurement/{metric_type}",
 		QoS:   1,
+		Codec: pluginapi.MQTTCodecJSON,
 	}
 }
 
-// Handle processes an incoming measurement message.
-func (s *MeasurementSubscriber) Handle(ctx context.Context, topic string, payload []byte) error {
-	parts := strings.Split(topic, "/")
-	source := parts[0]
-	sourceID := parts[1]
-	metricType := parts[3]
+// NewValue returns the value a measurement payload is decoded into.
+func (s *MeasurementSubscriber) NewValue() any {
+	return &sensorReading{}
+}
+
+// Handle is not used: the hub delivers measurements decoded, through HandleMessage.
+func (s *MeasurementSubscriber) Handle(_ context.Context, topic string, _ []byte) error {
+	return fmt.Errorf("%w: %s", errUntypedDelivery, topic)
+}
+
+// HandleMessage processes an incoming measurement message.
+func (s *MeasurementSubscriber) HandleMessage(
+	ctx context.Context,
+	msg pluginapi.MQTTMessage,
+) error {
+	sourceID := msg.Params["source_id"]
+	metricType := msg.Params["metric_type"]
 
-	sourceType, err := model.ParseSourceType(source)
+	sourceType, err := model.ParseSourceType(msg.Params["source_type"])
 	if err != nil {
 		return fmt.Errorf("parsing source type: %w", err)
 	}
 
-	var reading sensorReading
-	if err := json.Unmarshal(payload, &reading); err != nil {
-		return fmt.Errorf("parsing payload: %w", err)
+	reading, ok := msg.Value.(*sensorReading)
+	if !ok {
+		return fmt.Errorf("%w: %T", errUnexpectedValue, msg.Value)
 	}
 
 	s.logger.Info(
@@ -12,6 +12,12 @@ import (
 // MeasurementRepository defines the data access contract for Measurement entities.
 type MeasurementRepository interface {
 	Insert(ctx context.Context, entity *model.Measurement) error
+	GetLatest(
+		ctx context.Context,
+		sourceType model.SourceType,
+		sourceID string,
+		metricType string,
+	) (*model.Measurement, error)
 }
 
 // Measurement is a SQL-based implementation of MeasurementRepository.
@@ -40,3 +46,27 @@ func (r *Measurement) Insert(ctx context.Context, entity *model.Measurement) err
 
 	return nil
 }
+
+// GetLatest retrieves the most recent Measurement of a metric for the given source.
+func (r *Measurement) GetLatest(
+	ctx context.Context,
+	sourceType model.SourceType,
+	sourceID string,
+	metricType string,
+) (*model.Measurement, error) {
+	var entity model.Measurement
+
+	query := `
+		SELECT time, source_type, source_id, metric_type, value
+		FROM measurements
+		WHERE source_type = $1 AND source_id = $2 AND metric_type = $3
+		ORDER BY time DESC
+		LIMIT 1;
+	`
+
+	if err := r.tx.GetContext(ctx, &entity, query, sourceType, sourceID, metricType); err != nil {
+		return nil, fmt.Errorf("getting latest Measurement: %w", err)
+	}
+
+	return &entity, nil
+}
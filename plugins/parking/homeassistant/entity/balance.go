@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/abgeo/maroid/libs/pluginapi"
+	"github.com/abgeo/maroid/plugins/parking/service"
+)
+
+const balanceStateInterval = 15 * time.Minute
+
+// Balance is a sensor that reports the parking account balance.
+type Balance struct {
+	apiClientSvc service.APIClientService
+}
+
+var _ pluginapi.HomeAssistantStateEntity = (*Balance)(nil)
+
+// NewBalance creates a new Balance.
+func NewBalance(apiClientSvc service.APIClientService) *Balance {
+	return &Balance{
+		apiClientSvc: apiClientSvc,
+	}
+}
+
+// Meta returns the metadata for the entity.
+func (e *Balance) Meta() pluginapi.HomeAssistantEntityMeta {
+	return pluginapi.HomeAssistantEntityMeta{
+		ID:                "balance",
+		Component:         pluginapi.HomeAssistantSensor,
+		Name:              "Parking balance",
+		DeviceClass:       "monetary",
+		UnitOfMeasurement: "GEL",
+		Icon:              "mdi:wallet",
+		StateInterval:     balanceStateInterval,
+	}
+}
+
+// State returns the current balance.
+func (e *Balance) State(ctx context.Context) (string, error) {
+	person, err := e.apiClientSvc.GetPerson(ctx)
+	if err != nil {
+		return "", fmt.Errorf("fetching person: %w", err)
+	}
+
+	return strconv.FormatFloat(person.BalanceAmount, 'f', 2, 64), nil
+}
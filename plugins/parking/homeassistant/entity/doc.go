@@ -0,0 +1,2 @@
+// Package entity provides the Home Assistant entities of the parking plugin.
+package entity
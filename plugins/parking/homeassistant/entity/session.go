@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abgeo/maroid/libs/pluginapi"
+	"github.com/abgeo/maroid/plugins/parking/service"
+)
+
+const sessionStateInterval = time.Minute
+
+// Session is a binary sensor that is on while a parking session is active.
+type Session struct {
+	apiClientSvc service.APIClientService
+}
+
+var _ pluginapi.HomeAssistantStateEntity = (*Session)(nil)
+
+// NewSession creates a new Session.
+func NewSession(apiClientSvc service.APIClientService) *Session {
+	return &Session{
+		apiClientSvc: apiClientSvc,
+	}
+}
+
+// Meta returns the metadata for the entity.
+func (e *Session) Meta() pluginapi.HomeAssistantEntityMeta {
+	return pluginapi.HomeAssistantEntityMeta{
+		ID:            "session",
+		Component:     pluginapi.HomeAssistantBinarySensor,
+		Name:          "Parking session",
+		DeviceClass:   "running",
+		Icon:          "mdi:car-clock",
+		StateInterval: sessionStateInterval,
+	}
+}
+
+// State reports whether a parking session is active.
+func (e *Session) State(ctx context.Context) (string, error) {
+	session, err := e.apiClientSvc.GetActiveSession(ctx)
+	if err != nil {
+		return "", fmt.Errorf("fetching active session: %w", err)
+	}
+
+	if session == nil {
+		return pluginapi.HomeAssistantOff, nil
+	}
+
+	return pluginapi.HomeAssistantOn, nil
+}
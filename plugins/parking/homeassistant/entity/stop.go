@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abgeo/maroid/libs/pluginapi"
+	"github.com/abgeo/maroid/plugins/parking/service"
+)
+
+// Stop is a button that stops the active parking session.
+type Stop struct {
+	apiClientSvc service.APIClientService
+}
+
+var _ pluginapi.HomeAssistantButtonEntity = (*Stop)(nil)
+
+// NewStop creates a new Stop.
+func NewStop(apiClientSvc service.APIClientService) *Stop {
+	return &Stop{
+		apiClientSvc: apiClientSvc,
+	}
+}
+
+// Meta returns the metadata for the entity.
+func (e *Stop) Meta() pluginapi.HomeAssistantEntityMeta {
+	return pluginapi.HomeAssistantEntityMeta{
+		ID:        "stop",
+		Component: pluginapi.HomeAssistantButton,
+		Name:      "Stop parking",
+		Icon:      "mdi:car-off",
+	}
+}
+
+// Press stops the active parking session; it does nothing when no session is active.
+func (e *Stop) Press(ctx context.Context) error {
+	session, err := e.apiClientSvc.GetActiveSession(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching active session: %w", err)
+	}
+
+	if session == nil {
+		return nil
+	}
+
+	if _, err = e.apiClientSvc.StopParking(ctx, session.ID); err != nil {
+		return fmt.Errorf("stopping parking session: %w", err)
+	}
+
+	return nil
+}
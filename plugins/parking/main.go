@@ -10,6 +10,7 @@ import (
 	telegramconversationapi "github.com/abgeo/maroid/libs/pluginapi/telegram/conversation"
 	"github.com/abgeo/maroid/libs/pluginconfig"
 	"github.com/abgeo/maroid/plugins/parking/config"
+	"github.com/abgeo/maroid/plugins/parking/homeassistant/entity"
 	"github.com/abgeo/maroid/plugins/parking/service"
 	"github.com/abgeo/maroid/plugins/parking/telegram/command"
 	"github.com/abgeo/maroid/plugins/parking/telegram/conversation"
@@ -25,6 +26,7 @@ type ParkingPlugin struct {
 
 var (
 	_ pluginapi.Plugin                     = (*ParkingPlugin)(nil)
+	_ pluginapi.HomeAssistantEntityPlugin  = (*ParkingPlugin)(nil)
 	_ pluginapi.TelegramCommandPlugin      = (*ParkingPlugin)(nil)
 	_ pluginapi.TelegramConversationPlugin = (*ParkingPlugin)(nil)
 )
@@ -81,3 +83,11 @@ func (p *ParkingPlugin) TelegramConversations() ([]telegramconversationapi.Conve
 		conversation.NewParkingConversation(p.telegramBot, p.apiClientSvc),
 	}, nil
 }
+
+func (p *ParkingPlugin) HomeAssistantEntities() ([]pluginapi.HomeAssistantEntity, error) {
+	return []pluginapi.HomeAssistantEntity{
+		entity.NewSession(p.apiClientSvc),
+		entity.NewBalance(p.apiClientSvc),
+		entity.NewStop(p.apiClientSvc),
+	}, nil
+}
@@ -25,7 +25,7 @@ type ContributionsCollector struct {
 	apiClientSvc service.APIClientService
 }
 
-var _ pluginapi.CronJob = (*ContributionsCollector)(nil)
+var _ pluginapi.BackfillableJob = (*ContributionsCollector)(nil)
 
 // NewContributionsCollector creates a new ContributionsCollector job instance.
 func NewContributionsCollector(
@@ -58,13 +58,22 @@ func (j *ContributionsCollector) Meta() pluginapi.CronJobMeta {
 	}
 }
 
-// Run executes the job.
+// Run executes the job for the previous calendar month.
 func (j *ContributionsCollector) Run(ctx context.Context) error {
+	return j.collect(ctx, pluginapi.PreviousMonthPeriod(time.Now()))
+}
+
+// Backfill collects contributions for the given period.
+func (j *ContributionsCollector) Backfill(ctx context.Context, period pluginapi.Period) error {
+	return j.collect(ctx, period)
+}
+
+func (j *ContributionsCollector) collect(ctx context.Context, period pluginapi.Period) error {
 	if err := j.authenticate(ctx); err != nil {
 		return err
 	}
 
-	contributions, err := j.fetchContributions(ctx)
+	contributions, err := j.fetchContributions(ctx, period)
 	if err != nil {
 		return err
 	}
@@ -93,10 +102,11 @@ func (j *ContributionsCollector) authenticate(ctx context.Context) error {
 
 func (j *ContributionsCollector) fetchContributions(
 	ctx context.Context,
+	period pluginapi.Period,
 ) ([]dto.Contribution, error) {
 	const pageSize = 10
 
-	startDate, endDate := getPreviousMonthPeriod()
+	startDate, endDate := period.From, period.To
 
 	j.logger.Info(
 		"fetching contributions",
@@ -171,14 +181,3 @@ func (j *ContributionsCollector) insertContributionsInTx(
 
 	return nil
 }
-
-func getPreviousMonthPeriod() (time.Time, time.Time) {
-	now := time.Now()
-	location := now.Location()
-
-	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
-	lastMonthEnd := firstOfMonth.AddDate(0, 0, -1)
-	lastMonthStart := time.Date(lastMonthEnd.Year(), lastMonthEnd.Month(), 1, 0, 0, 0, 0, location)
-
-	return lastMonthStart, lastMonthEnd
-}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/abgeo/maroid/libs/pluginapi"
+	"github.com/abgeo/maroid/plugins/tbilisi-energy/model"
+	"github.com/abgeo/maroid/plugins/tbilisi-energy/repository"
+)
+
+const balanceStateInterval = time.Hour
+
+// Balance is a sensor that reports the balance after the latest collected transaction.
+type Balance struct {
+	db *pluginapi.PluginDB
+}
+
+var _ pluginapi.HomeAssistantStateEntity = (*Balance)(nil)
+
+// NewBalance creates a new Balance.
+func NewBalance(db *pluginapi.PluginDB) *Balance {
+	return &Balance{
+		db: db,
+	}
+}
+
+// Meta returns the metadata for the entity.
+func (e *Balance) Meta() pluginapi.HomeAssistantEntityMeta {
+	return pluginapi.HomeAssistantEntityMeta{
+		ID:                "balance",
+		Component:         pluginapi.HomeAssistantSensor,
+		Name:              "Gas balance",
+		DeviceClass:       "monetary",
+		UnitOfMeasurement: "GEL",
+		Icon:              "mdi:fire",
+		StateInterval:     balanceStateInterval,
+	}
+}
+
+// State returns the balance of the latest transaction.
+func (e *Balance) State(ctx context.Context) (string, error) {
+	var transaction *model.Transaction
+
+	err := e.db.WithTx(ctx, func(tx *sqlx.Tx) error {
+		var err error
+
+		transaction, err = repository.NewTransaction(tx).GetLatest(ctx)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return "", fmt.Errorf("fetching latest transaction: %w", err)
+	}
+
+	return strconv.FormatFloat(transaction.Balance, 'f', 2, 64), nil
+}
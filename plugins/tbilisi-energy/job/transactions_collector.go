@@ -28,7 +28,7 @@ type TransactionsCollector struct {
 	apiClientSvc service.APIClientService
 }
 
-var _ pluginapi.CronJob = (*TransactionsCollector)(nil)
+var _ pluginapi.BackfillableJob = (*TransactionsCollector)(nil)
 
 // NewTransactionsCollector creates a new TransactionsCollector job instance.
 func NewTransactionsCollector(
@@ -61,28 +61,47 @@ func (j *TransactionsCollector) Meta() pluginapi.CronJobMeta {
 	}
 }
 
-// Run executes the job.
+// Run executes the job for the previous calendar month.
 func (j *TransactionsCollector) Run(ctx context.Context) error {
-	if err := j.authenticate(ctx); err != nil {
+	transactions, err := j.collect(ctx, pluginapi.PreviousMonthPeriod(time.Now()))
+	if err != nil {
 		return err
 	}
 
-	transactions, err := j.fetchTransactions(ctx)
-	if err != nil {
+	if err = j.sendNotification(ctx, transactions); err != nil {
 		return err
 	}
 
-	if err = j.storeTransactions(ctx, transactions); err != nil {
-		return err
+	return nil
+}
+
+// Backfill collects transactions for the given period without sending notifications.
+func (j *TransactionsCollector) Backfill(ctx context.Context, period pluginapi.Period) error {
+	_, err := j.collect(ctx, period)
+
+	return err
+}
+
+func (j *TransactionsCollector) collect(
+	ctx context.Context,
+	period pluginapi.Period,
+) ([]dto.Transaction, error) {
+	if err := j.authenticate(ctx); err != nil {
+		return nil, err
 	}
 
-	j.logger.Info("transaction collection completed successfully")
+	transactions, err := j.fetchTransactions(ctx, period)
+	if err != nil {
+		return nil, err
+	}
 
-	if err = j.sendNotification(ctx, transactions); err != nil {
-		return err
+	if err = j.storeTransactions(ctx, transactions); err != nil {
+		return nil, err
 	}
 
-	return nil
+	j.logger.Info("transaction collection completed successfully")
+
+	return transactions, nil
 }
 
 func (j *TransactionsCollector) authenticate(ctx context.Context) error {
@@ -98,10 +117,12 @@ func (j *TransactionsCollector) authenticate(ctx context.Context) error {
 	return nil
 }
 
-func (j *TransactionsCollector) fetchTransactions(ctx context.Context) ([]dto.Transaction, error) {
-	startDate, endDate := getPreviousMonthPeriod()
-	dateFrom := startDate.Format("2006-01-02")
-	dateTo := endDate.Format("2006-01-02")
+func (j *TransactionsCollector) fetchTransactions(
+	ctx context.Context,
+	period pluginapi.Period,
+) ([]dto.Transaction, error) {
+	dateFrom := period.From.Format("2006-01-02")
+	dateTo := period.To.Format("2006-01-02")
 
 	j.logger.Info(
 		"fetching transactions",
@@ -175,17 +196,6 @@ func (j *TransactionsCollector) insertTransactionsInTx(
 	return nil
 }
 
-func getPreviousMonthPeriod() (time.Time, time.Time) {
-	now := time.Now()
-	location := now.Location()
-
-	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
-	lastMonthEnd := firstOfMonth.AddDate(0, 0, -1)
-	lastMonthStart := time.Date(lastMonthEnd.Year(), lastMonthEnd.Month(), 1, 0, 0, 0, 0, location)
-
-	return lastMonthStart, lastMonthEnd
-}
-
 func (j *TransactionsCollector) sendNotification(
 	ctx context.Context,
 	transactions []dto.Transaction,
@@ -216,12 +226,12 @@ func (j *TransactionsCollector) sendUtilityBillNotification(
 		return err
 	}
 
-	err = j.notifier.Send(
+	_, err = j.notifier.Send(
 		ctx,
 		"utility_bills",
 		notifierapi.Message{
 			Title:       "თბილისი ენერჯი | ქვითარი",
-			Body:        buildNotificationMessage(transaction),
+			RichBody:    buildNotificationMessage(transaction),
 			Attachments: attachments,
 		},
 	)
@@ -299,16 +309,16 @@ func (j *TransactionsCollector) buildMeterPhotoAttachment(
 	}, nil
 }
 
-func buildNotificationMessage(transaction dto.Transaction) string {
-	return fmt.Sprintf(`ბუნებრივი აირის მოხმარების ყოველთვიური ქვითარი.
-
-<b>თარიღი</b>: %s
-<b>მრიცხველის ჩვენება</b>: %.0f მ³
-<b>მოხმარება</b>: %.0f მ³
-<b>სულ გადასახადი</b>: %.2f ₾`,
-		transaction.OperationDateString,
-		transaction.MeterReading,
-		transaction.Consumption,
-		transaction.Amount,
-	)
+func buildNotificationMessage(transaction dto.Transaction) notifierapi.RichText {
+	return notifierapi.RichText{
+		notifierapi.Text("ბუნებრივი აირის მოხმარების ყოველთვიური ქვითარი.\n\n"),
+		notifierapi.Bold("თარიღი"),
+		notifierapi.Text(": " + transaction.OperationDateString + "\n"),
+		notifierapi.Bold("მრიცხველის ჩვენება"),
+		notifierapi.Text(fmt.Sprintf(": %.0f მ³\n", transaction.MeterReading)),
+		notifierapi.Bold("მოხმარება"),
+		notifierapi.Text(fmt.Sprintf(": %.0f მ³\n", transaction.Consumption)),
+		notifierapi.Bold("სულ გადასახადი"),
+		notifierapi.Text(fmt.Sprintf(": %.2f ₾", transaction.Amount)),
+	}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/abgeo/maroid/libs/pluginconfig"
 	"github.com/abgeo/maroid/plugins/tbilisi-energy/config"
 	"github.com/abgeo/maroid/plugins/tbilisi-energy/db"
+	"github.com/abgeo/maroid/plugins/tbilisi-energy/homeassistant/entity"
 	"github.com/abgeo/maroid/plugins/tbilisi-energy/job"
 	"github.com/abgeo/maroid/plugins/tbilisi-energy/service"
 )
@@ -24,9 +25,10 @@ type TbilisiEnergyPlugin struct {
 }
 
 var (
-	_ pluginapi.Plugin          = (*TbilisiEnergyPlugin)(nil)
-	_ pluginapi.CronPlugin      = (*TbilisiEnergyPlugin)(nil)
-	_ pluginapi.MigrationPlugin = (*TbilisiEnergyPlugin)(nil)
+	_ pluginapi.Plugin                    = (*TbilisiEnergyPlugin)(nil)
+	_ pluginapi.CronPlugin                = (*TbilisiEnergyPlugin)(nil)
+	_ pluginapi.HomeAssistantEntityPlugin = (*TbilisiEnergyPlugin)(nil)
+	_ pluginapi.MigrationPlugin           = (*TbilisiEnergyPlugin)(nil)
 )
 
 // New creates a plugin instance.
@@ -81,6 +83,12 @@ func (p *TbilisiEnergyPlugin) CronJobs() ([]pluginapi.CronJob, error) {
 	}, nil
 }
 
+func (p *TbilisiEnergyPlugin) HomeAssistantEntities() ([]pluginapi.HomeAssistantEntity, error) {
+	return []pluginapi.HomeAssistantEntity{
+		entity.NewBalance(p.db),
+	}, nil
+}
+
 func (p *TbilisiEnergyPlugin) Migrations() (fs.FS, error) {
 	migrationsFS, err := fs.Sub(db.Migrations, "migrations")
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 // TransactionRepository defines the data access contract for Transaction entities.
 type TransactionRepository interface {
 	Insert(ctx context.Context, entity *model.Transaction) error
+	GetLatest(ctx context.Context) (*model.Transaction, error)
 }
 
 // Transaction is a SQL-based implementation of TransactionRepository.
@@ -47,3 +48,22 @@ func (r *Transaction) Insert(ctx context.Context, entity *model.Transaction) err
 
 	return nil
 }
+
+// GetLatest retrieves the most recent Transaction.
+func (r *Transaction) GetLatest(ctx context.Context) (*model.Transaction, error) {
+	var entity model.Transaction
+
+	query := `
+		SELECT hash, consumption, amount, meter_reading, balance, date,
+		       billing_document_url, meter_photo_url, transaction_type_id
+		FROM transactions
+		ORDER BY date DESC
+		LIMIT 1;
+	`
+
+	if err := r.tx.GetContext(ctx, &entity, query); err != nil {
+		return nil, fmt.Errorf("getting latest Transaction: %w", err)
+	}
+
+	return &entity, nil
+}
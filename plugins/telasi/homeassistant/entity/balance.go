@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/abgeo/maroid/libs/pluginapi"
+	"github.com/abgeo/maroid/plugins/telasi/config"
+	"github.com/abgeo/maroid/plugins/telasi/dto"
+	"github.com/abgeo/maroid/plugins/telasi/service"
+)
+
+const balanceStateInterval = time.Hour
+
+var errCustomerNotFound = errors.New("customer not found")
+
+// Balance is a sensor that reports one of the balances of the configured account.
+type Balance struct {
+	config       *config.Config
+	apiClientSvc service.APIClientService
+	meta         pluginapi.HomeAssistantEntityMeta
+	value        func(customer *dto.CustomerResponse) string
+}
+
+var _ pluginapi.HomeAssistantStateEntity = (*Balance)(nil)
+
+// NewElectricityBalance creates a Balance that reports the electricity balance.
+func NewElectricityBalance(cfg *config.Config, apiClientSvc service.APIClientService) *Balance {
+	return newBalance(cfg, apiClientSvc, "electricity_balance", "Electricity balance", "mdi:flash",
+		func(customer *dto.CustomerResponse) string { return customer.TelasiBalance },
+	)
+}
+
+// NewWaterBalance creates a Balance that reports the water supply balance.
+func NewWaterBalance(cfg *config.Config, apiClientSvc service.APIClientService) *Balance {
+	return newBalance(cfg, apiClientSvc, "water_balance", "Water balance", "mdi:water",
+		func(customer *dto.CustomerResponse) string { return customer.WaterBalance },
+	)
+}
+
+// NewCleaningBalance creates a Balance that reports the waste collection balance.
+func NewCleaningBalance(cfg *config.Config, apiClientSvc service.APIClientService) *Balance {
+	return newBalance(cfg, apiClientSvc, "cleaning_balance", "Cleaning balance", "mdi:trash-can",
+		func(customer *dto.CustomerResponse) string { return customer.CleaningBalance },
+	)
+}
+
+func newBalance(
+	cfg *config.Config,
+	apiClientSvc service.APIClientService,
+	id string,
+	name string,
+	icon string,
+	value func(customer *dto.CustomerResponse) string,
+) *Balance {
+	return &Balance{
+		config:       cfg,
+		apiClientSvc: apiClientSvc,
+		meta: pluginapi.HomeAssistantEntityMeta{
+			ID:                id,
+			Component:         pluginapi.HomeAssistantSensor,
+			Name:              name,
+			DeviceClass:       "monetary",
+			UnitOfMeasurement: "GEL",
+			Icon:              icon,
+			StateInterval:     balanceStateInterval,
+		},
+		value: value,
+	}
+}
+
+// Meta returns the metadata for the entity.
+func (e *Balance) Meta() pluginapi.HomeAssistantEntityMeta {
+	return e.meta
+}
+
+// State returns the current balance of the configured account.
+func (e *Balance) State(ctx context.Context) (string, error) {
+	token, err := e.apiClientSvc.Authenticate(ctx, e.config.Email, e.config.Password)
+	if err != nil {
+		return "", fmt.Errorf("authentication failed: %w", err)
+	}
+
+	e.apiClientSvc.SetAuthToken(token)
+
+	customers, err := e.apiClientSvc.GetCustomers(ctx)
+	if err != nil {
+		return "", fmt.Errorf("fetching customers: %w", err)
+	}
+
+	for _, customer := range customers {
+		if customer.AccountNumber != e.config.AccountNumber {
+			continue
+		}
+
+		balance, err := strconv.ParseFloat(e.value(&customer), 64)
+		if err != nil {
+			return "", fmt.Errorf("parsing balance: %w", err)
+		}
+
+		return strconv.FormatFloat(balance, 'f', 2, 64), nil
+	}
+
+	return "", fmt.Errorf("%w: %s", errCustomerNotFound, e.config.AccountNumber)
+}
@@ -0,0 +1,2 @@
+// Package entity provides the Home Assistant entities of the telasi plugin.
+package entity
@@ -25,7 +25,7 @@ type BillingItemsCollector struct {
 	apiClientSvc service.APIClientService
 }
 
-var _ pluginapi.CronJob = (*BillingItemsCollector)(nil)
+var _ pluginapi.BackfillableJob = (*BillingItemsCollector)(nil)
 
 // NewBillingItemsCollector creates a new BillingItemsCollector job instance.
 func NewBillingItemsCollector(
@@ -58,28 +58,47 @@ func (j *BillingItemsCollector) Meta() pluginapi.CronJobMeta {
 	}
 }
 
-// Run executes the job.
+// Run executes the job for the previous calendar month.
 func (j *BillingItemsCollector) Run(ctx context.Context) error {
-	if err := j.authenticate(ctx); err != nil {
+	billingItems, err := j.collect(ctx, pluginapi.PreviousMonthPeriod(time.Now()))
+	if err != nil {
 		return err
 	}
 
-	billingItems, err := j.fetchBillingItems(ctx)
-	if err != nil {
+	if err = j.sendNotification(ctx, billingItems); err != nil {
 		return err
 	}
 
-	if err = j.storeBillingItems(ctx, billingItems); err != nil {
-		return err
+	return nil
+}
+
+// Backfill collects billing items for the given period without sending notifications.
+func (j *BillingItemsCollector) Backfill(ctx context.Context, period pluginapi.Period) error {
+	_, err := j.collect(ctx, period)
+
+	return err
+}
+
+func (j *BillingItemsCollector) collect(
+	ctx context.Context,
+	period pluginapi.Period,
+) ([]dto.BillingItem, error) {
+	if err := j.authenticate(ctx); err != nil {
+		return nil, err
 	}
 
-	j.logger.Info("billing item collection completed successfully")
+	billingItems, err := j.fetchBillingItems(ctx, period)
+	if err != nil {
+		return nil, err
+	}
 
-	if err = j.sendNotification(ctx, billingItems); err != nil {
-		return err
+	if err = j.storeBillingItems(ctx, billingItems); err != nil {
+		return nil, err
 	}
 
-	return nil
+	j.logger.Info("billing item collection completed successfully")
+
+	return billingItems, nil
 }
 
 func (j *BillingItemsCollector) authenticate(ctx context.Context) error {
@@ -95,10 +114,12 @@ func (j *BillingItemsCollector) authenticate(ctx context.Context) error {
 	return nil
 }
 
-func (j *BillingItemsCollector) fetchBillingItems(ctx context.Context) ([]dto.BillingItem, error) {
-	startDate, endDate := getPreviousMonthPeriod()
-	dateFrom := startDate.Format("2006-01-02")
-	dateTo := endDate.Format("2006-01-02")
+func (j *BillingItemsCollector) fetchBillingItems(
+	ctx context.Context,
+	period pluginapi.Period,
+) ([]dto.BillingItem, error) {
+	dateFrom := period.From.Format("2006-01-02")
+	dateTo := period.To.Format("2006-01-02")
 
 	j.logger.Info(
 		"fetching billing items",
@@ -192,12 +213,12 @@ func (j *BillingItemsCollector) sendUtilityBillNotification(
 	ctx context.Context,
 	billingItem dto.BillingItem,
 ) error {
-	err := j.notifier.Send(
+	_, err := j.notifier.Send(
 		ctx,
 		"utility_bills",
 		notifierapi.Message{
-			Title: "თელასი | ქვითარი",
-			Body:  buildNotificationMessage(billingItem),
+			Title:    "თელასი | ქვითარი",
+			RichBody: buildNotificationMessage(billingItem),
 		},
 	)
 	if err != nil {
@@ -207,27 +228,16 @@ func (j *BillingItemsCollector) sendUtilityBillNotification(
 	return nil
 }
 
-func getPreviousMonthPeriod() (time.Time, time.Time) {
-	now := time.Now()
-	location := now.Location()
-
-	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
-	lastMonthEnd := firstOfMonth.AddDate(0, 0, -1)
-	lastMonthStart := time.Date(lastMonthEnd.Year(), lastMonthEnd.Month(), 1, 0, 0, 0, 0, location)
-
-	return lastMonthStart, lastMonthEnd
-}
-
-func buildNotificationMessage(item dto.BillingItem) string {
-	return fmt.Sprintf(`ელექტრო ენერგიის მოხმარების ყოველთვიური ქვითარი.
-	
-	<b>თარიღი</b>: %s
-	<b>მრიცხველის ჩვენება</b>: %s კვტ/სთ
-	<b>მოხმარება</b>: %s კვტ/სთ
-	<b>სულ გადასახადი</b>: %s ₾`,
-		item.EnterDate,
-		item.Reading,
-		item.Consumption,
-		item.Amount,
-	)
+func buildNotificationMessage(item dto.BillingItem) notifierapi.RichText {
+	return notifierapi.RichText{
+		notifierapi.Text("ელექტრო ენერგიის მოხმარების ყოველთვიური ქვითარი.\n\n"),
+		notifierapi.Bold("თარიღი"),
+		notifierapi.Text(": " + item.EnterDate + "\n"),
+		notifierapi.Bold("მრიცხველის ჩვენება"),
+		notifierapi.Text(": " + item.Reading + " კვტ/სთ\n"),
+		notifierapi.Bold("მოხმარება"),
+		notifierapi.Text(": " + item.Consumption + " კვტ/სთ\n"),
+		notifierapi.Bold("სულ გადასახადი"),
+		notifierapi.Text(": " + item.Amount + " ₾"),
+	}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/abgeo/maroid/libs/pluginconfig"
 	"github.com/abgeo/maroid/plugins/telasi/config"
 	"github.com/abgeo/maroid/plugins/telasi/db"
+	"github.com/abgeo/maroid/plugins/telasi/homeassistant/entity"
 	"github.com/abgeo/maroid/plugins/telasi/job"
 	"github.com/abgeo/maroid/plugins/telasi/service"
 )
@@ -24,9 +25,10 @@ type TelasiPlugin struct {
 }
 
 var (
-	_ pluginapi.Plugin          = (*TelasiPlugin)(nil)
-	_ pluginapi.CronPlugin      = (*TelasiPlugin)(nil)
-	_ pluginapi.MigrationPlugin = (*TelasiPlugin)(nil)
+	_ pluginapi.Plugin                    = (*TelasiPlugin)(nil)
+	_ pluginapi.CronPlugin                = (*TelasiPlugin)(nil)
+	_ pluginapi.HomeAssistantEntityPlugin = (*TelasiPlugin)(nil)
+	_ pluginapi.MigrationPlugin           = (*TelasiPlugin)(nil)
 )
 
 // New creates a plugin instance.
@@ -81,6 +83,14 @@ func (p *TelasiPlugin) CronJobs() ([]pluginapi.CronJob, error) {
 	}, nil
 }
 
+func (p *TelasiPlugin) HomeAssistantEntities() ([]pluginapi.HomeAssistantEntity, error) {
+	return []pluginapi.HomeAssistantEntity{
+		entity.NewElectricityBalance(p.config, p.apiClientSvc),
+		entity.NewWaterBalance(p.config, p.apiClientSvc),
+		entity.NewCleaningBalance(p.config, p.apiClientSvc),
+	}, nil
+}
+
 func (p *TelasiPlugin) Migrations() (fs.FS, error) {
 	migrationsFS, err := fs.Sub(db.Migrations, "migrations")
 	if err != nil {